@@ -25,6 +25,7 @@ import (
 	"image"
 	"p86l/assets"
 	"p86l/internal/debug"
+	"p86l/internal/version"
 	"p86l/internal/widget"
 
 	"github.com/hajimehoshi/guigui"
@@ -40,6 +41,7 @@ type About struct {
 	leadImage basicwidget.Image
 	devText   basicwidget.Text
 	devImage  basicwidget.Image
+	buildText basicwidget.Text
 
 	err *debug.Error
 }
@@ -73,6 +75,9 @@ func (a *About) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppe
 
 	a.devText.SetText(WrapText(context, "Launcher Developer - realskyquest - Sky", w-int(1*u)))
 
+	a.buildText.SetText(WrapText(context, version.Get().String(), w-int(1*u)))
+	a.buildText.SetSelectable(true)
+
 	a.vLayout.SetHorizontalAlign(widget.HorizontalAlignCenter)
 
 	a.vLayout.SetWidth(context, w-int(1*u))
@@ -84,6 +89,7 @@ func (a *About) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppe
 		{Widget: &a.leadText},
 		{Widget: &a.devImage},
 		{Widget: &a.devText},
+		{Widget: &a.buildText},
 	})
 	appender.AppendChildWidget(&a.vLayout)
 }
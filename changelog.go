@@ -22,31 +22,63 @@
 package p86l
 
 import (
+	"fmt"
 	"image"
+	"p86l/configs"
+	ESApp "p86l/internal/app"
 	"p86l/internal/debug"
+	"p86l/internal/release"
 	"p86l/internal/widget"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/guigui"
 	"github.com/hajimehoshi/guigui/basicwidget"
-	"github.com/pkg/browser"
 )
 
 type Changelog struct {
 	guigui.DefaultWidget
 
 	vLayout         widget.VerticalLayout
+	offlineText     basicwidget.Text
 	changelogText   basicwidget.Text
 	vButtonLayout   widget.VerticalLayout
 	changelogButton basicwidget.TextButton
+	latestButton    basicwidget.TextButton
+
+	// release, when set, is shown instead of the cached changelog.
+	release atomic.Pointer[release.Release]
+}
+
+// ShowRelease fetches and shows the notes of the release tagged tag, once
+// the launcher is online when it is not.
+func (c *Changelog) ShowRelease(tag string) {
+	app.Queue("changelog:"+tag, func() {
+		rel, err := app.ReleaseSource(githubClient).GetRelease(githubContext, tag)
+		if err != nil {
+			app.Debug.SetToast(app.Debug.New(err, debug.NetworkError, debug.ErrChangelogNetwork))
+			return
+		}
+		c.release.Store(rel)
+	})
 }
 
 func (c *Changelog) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppender) {
+	rel := c.release.Load()
 	c.changelogButton.SetOnDown(func() {
-		go func() {
-			if err := browser.OpenURL(app.Cache.Changelog.URL); err != nil {
-				app.Debug.SetToast(app.Debug.New(err, debug.AppError, debug.ErrBrowserOpen))
-			}
-		}()
+		url := ""
+		if rel != nil {
+			url = rel.URL
+		} else if app.Cache.Changelog != nil {
+			url = app.Cache.Changelog.URL
+		}
+		if url == "" {
+			return
+		}
+		if err := ESApp.ValidLink(configs.Link{Label: "Changelog", URL: url}); err != nil {
+			app.Debug.SetToast(app.Debug.New(err, debug.AppError, debug.ErrBrowserOpen))
+			return
+		}
+		openURL(url)
 	})
 
 	u := float64(basicwidget.UnitSize(context))
@@ -59,25 +91,41 @@ func (c *Changelog) Layout(context *guigui.Context, appender *guigui.ChildWidget
 	c.vLayout.SetWidth(context, w-int(1*u))
 	guigui.SetPosition(&c.vLayout, pt)
 
-	if app.Cache.Changelog != nil {
+	c.latestButton.SetOnDown(func() {
+		c.release.Store(nil)
+	})
+
+	if rel != nil {
+		c.changelogText.SetText(WrapText(context, rel.Tag+"\n\n"+rel.Body, w-int(1*u)))
+	} else if app.Cache.Changelog != nil {
 		changelogTextData := WrapText(context, app.Cache.Changelog.Body, w-int(1*u))
 		c.changelogText.SetText(changelogTextData)
 	} else {
-		c.changelogText.SetText("NO INTERNET")
+		c.changelogText.SetText(networkStatusText())
 	}
 
 	c.changelogButton.SetText("View changelog")
 	c.vButtonLayout.SetWidth(context, w-int(1*u))
 	c.vButtonLayout.SetHorizontalAlign(widget.HorizontalAlignCenter)
 
-	c.vButtonLayout.SetItems([]*widget.LayoutItem{
-		{Widget: &c.changelogButton},
-	})
+	buttons := []*widget.LayoutItem{{Widget: &c.changelogButton}}
+	if rel != nil {
+		c.latestButton.SetText("Latest changelog")
+		buttons = append(buttons, &widget.LayoutItem{Widget: &c.latestButton})
+	}
+	c.vButtonLayout.SetItems(buttons)
 
-	c.vLayout.SetItems([]*widget.LayoutItem{
-		{Widget: &c.changelogText},
-		{Widget: &c.vButtonLayout},
-	})
+	var items []*widget.LayoutItem
+	if rel == nil && !app.IsInternet() && app.Cache.Changelog != nil {
+		c.offlineText.SetText(fmt.Sprintf("Offline, last updated %s", FormatAgo(app.Cache.Changelog.Timestamp)))
+		c.offlineText.SetBold(true)
+		items = append(items, &widget.LayoutItem{Widget: &c.offlineText})
+	}
+	items = append(items,
+		&widget.LayoutItem{Widget: &c.changelogText},
+		&widget.LayoutItem{Widget: &c.vButtonLayout},
+	)
+	c.vLayout.SetItems(items)
 	appender.AppendChildWidget(&c.vLayout)
 }
 
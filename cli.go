@@ -0,0 +1,558 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"p86l/configs"
+	ESApp "p86l/internal/app"
+	"p86l/internal/archive"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/release"
+	"p86l/internal/runner"
+	"p86l/internal/single"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Exit codes of the command line interface.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitNetwork      = 3
+	exitNotInstalled = 4
+	exitVerifyFailed = 5
+	exitRunning      = 6
+	exitOutdated     = 7
+)
+
+const cliUsage = `Usage: p86l <command> [flags] [instance]
+
+Commands:
+  install [instance]    install an instance, creating it if needed
+  update [instance]     install the newest release of installed instances
+  verify [instance]     check installed files against the install manifest
+  launch [instance]     start an installed instance
+  list                  list instances
+  changelog             print the changelog of the default instance
+
+Run "p86l <command> -h" for the flags of a command. Every command accepts
+-json. Exit codes: 0 success, 1 error, 2 usage, 3 network, 4 not installed,
+5 verification failed, 6 another launcher is running, 7 the release needs
+a newer launcher.
+`
+
+var cliCommands = []string{"install", "update", "verify", "launch", "list", "changelog", "help"}
+
+// IsCLI reports whether args, without the program name, start with a
+// command line interface command.
+func IsCLI(args []string) bool {
+	return len(args) > 0 && (slices.Contains(cliCommands, args[0]) || args[0] == "-h" || args[0] == "--help")
+}
+
+type cli struct {
+	stdout io.Writer
+	stderr io.Writer
+	json   bool
+}
+
+// forwardedLaunch returns the instance and options of a forwarded launch
+// command.
+func forwardedLaunch(args []string) (string, ESApp.LaunchOptions, bool) {
+	var opts ESApp.LaunchOptions
+	if len(args) == 0 || args[0] != "launch" {
+		return "", opts, false
+	}
+	fs := flag.NewFlagSet("launch", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Bool("json", false, "")
+	fs.BoolVar(&opts.SkipChecks, "force", false, "")
+	fs.StringVar(&opts.Profile, "profile", "", "")
+	dryRun := fs.Bool("dry-run", false, "")
+	if fs.Parse(args[1:]) != nil || fs.NArg() > 1 || *dryRun {
+		return "", opts, false
+	}
+	if fs.NArg() == 1 {
+		return fs.Arg(0), opts, true
+	}
+	return configs.DefaultInstance, opts, true
+}
+
+// ForwardCLI runs a command while another launcher holds the lock. Launch
+// commands are handed to it; the others could race it on the launcher data
+// and are refused.
+func ForwardCLI(args []string) int {
+	c := &cli{stdout: os.Stdout, stderr: os.Stderr, json: slices.Contains(args, "-json") || slices.Contains(args, "--json")}
+	name, _, ok := forwardedLaunch(args)
+	if !ok {
+		fmt.Fprintf(c.stderr, "p86l: %v, close it first\n", single.ErrLocked)
+		return exitRunning
+	}
+	if err := single.Forward(configs.AppName, args); err != nil {
+		fmt.Fprintf(c.stderr, "p86l: forward to the running launcher: %v\n", err)
+		return exitRunning
+	}
+	c.print(map[string]any{"name": name, "forwarded": true}, func(w io.Writer) {
+		fmt.Fprintf(w, "%s: launch forwarded to the running launcher\n", name)
+	})
+	return exitOK
+}
+
+// RunCLI runs a command without opening the window and returns the exit
+// code. Logs go to stderr so stdout only holds the command output.
+// Launches forwarded to lock, when it is not nil, are handled while the
+// command runs.
+func RunCLI(args []string, lock *single.Lock) int {
+	c := &cli{stdout: os.Stdout, stderr: os.Stderr}
+	if !IsCLI(args) {
+		fmt.Fprint(c.stderr, cliUsage)
+		return exitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(c.stderr, cliUsage)
+		return exitOK
+	}
+
+	if err := initApp(os.Stderr); err.Err != nil {
+		return c.fail(err)
+	}
+	if lock != nil {
+		go lock.Serve(handleForwarded)
+	}
+	command, args := args[0], args[1:]
+	// Commands that resolve releases follow the latest launcher manifest,
+	// or the cached one when it cannot be fetched.
+	if command == "install" || command == "update" || command == "list" {
+		if err := app.UpdateManifest(githubContext); err.Err != nil {
+			fmt.Fprintf(c.stderr, "p86l: launcher manifest: %v\n", err.Err)
+		}
+	}
+	switch command {
+	case "install":
+		return c.install(args)
+	case "update":
+		return c.update(args)
+	case "verify":
+		return c.verify(args)
+	case "launch":
+		return c.launch(args)
+	case "list":
+		return c.list(args)
+	default:
+		return c.changelog(args)
+	}
+}
+
+// flags parses the flags of command, which takes at most one instance name
+// unless instanceArg is false.
+func (c *cli) flags(command string, args []string, instanceArg bool, define func(*flag.FlagSet)) (string, bool) {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.BoolVar(&c.json, "json", false, "print JSON")
+	if define != nil {
+		define(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		return "", false
+	}
+	name := configs.DefaultInstance
+	switch {
+	case fs.NArg() == 1 && instanceArg:
+		name = fs.Arg(0)
+	case fs.NArg() > 0:
+		fmt.Fprintf(c.stderr, "%s: unexpected arguments %s\n", command, strings.Join(fs.Args(), " "))
+		return "", false
+	}
+	return name, true
+}
+
+func (c *cli) print(value any, text func(w io.Writer)) {
+	if c.json {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(value)
+		return
+	}
+	text(c.stdout)
+}
+
+func (c *cli) fail(err *debug.Error) int {
+	code := exitError
+	switch {
+	case err.Code == debug.ErrInstanceNotFound || err.Code == debug.ErrGameNotFound:
+		code = exitNotInstalled
+	case err.Code == debug.ErrLauncherOutdated:
+		code = exitOutdated
+	case err.Type == debug.NetworkError:
+		code = exitNetwork
+	}
+	if c.json {
+		c.print(map[string]any{"error": err.Err.Error(), "type": err.Type, "code": err.Code}, nil)
+	} else {
+		fmt.Fprintf(c.stderr, "p86l: %v (%s %d)\n", err.Err, err.Type, err.Code)
+	}
+	return code
+}
+
+// progress prints install progress to stderr in steps of ten percent.
+func (c *cli) progress(name string) ESApp.InstallProgress {
+	lastStage, lastStep := "", int64(-1)
+	return func(stage string, done, total int64) {
+		if c.json || total <= 0 {
+			return
+		}
+		step := done * 10 / total
+		if stage == lastStage && step == lastStep {
+			return
+		}
+		lastStage, lastStep = stage, step
+		fmt.Fprintf(c.stderr, "%s: %s %d%%\n", name, stage, step*10)
+	}
+}
+
+type cliInstance struct {
+	Name      string          `json:"name"`
+	Tag       string          `json:"tag,omitempty"`
+	Channel   release.Channel `json:"channel,omitempty"`
+	PinnedTag string          `json:"pinned_tag,omitempty"`
+	Dir       string          `json:"dir"`
+	Installed bool            `json:"installed"`
+	Update    string          `json:"update,omitempty"`
+}
+
+func (c *cli) instance(name string) cliInstance {
+	instance := app.Data.Instance(name)
+	dir, _ := app.InstanceDir(name)
+	result := cliInstance{
+		Name:      name,
+		Tag:       instance.Tag,
+		Channel:   instance.Channel,
+		PinnedTag: instance.PinnedTag,
+		Dir:       dir,
+		Installed: app.IsInstalled(name),
+	}
+	if rel := app.UpdateAvailable(name); rel != nil {
+		result.Update = rel.Tag
+	}
+	return result
+}
+
+func (c *cli) install(args []string) int {
+	var channel, tag, dir, kind, binary string
+	name, ok := c.flags("install", args, true, func(fs *flag.FlagSet) {
+		fs.StringVar(&channel, "channel", "", "release channel: stable, beta or pinned")
+		fs.StringVar(&tag, "tag", "", "install this release and pin the instance to it")
+		fs.StringVar(&dir, "dir", "", "install into this directory, which must be empty or a previous install, instead of the games directory")
+		fs.StringVar(&kind, "runner", "", "run the game natively or through wine or proton")
+		fs.StringVar(&binary, "runner-bin", "", "path of the wine binary or proton script")
+	})
+	if !ok {
+		return exitUsage
+	}
+
+	// The settings given here only stay when the install goes through.
+	previous := app.Data.Instance(name)
+	updated := data.Instance{Name: name, Channel: release.ChannelStable}
+	if previous != nil {
+		updated = *previous
+	}
+	if channel != "" {
+		if !slices.Contains(release.Channels, release.Channel(channel)) {
+			fmt.Fprintf(c.stderr, "install: unknown channel %q\n", channel)
+			return exitUsage
+		}
+		updated.Channel = release.Channel(channel)
+	}
+	if tag != "" {
+		updated.Channel, updated.PinnedTag = release.ChannelPinned, tag
+	}
+	if updated.Channel == release.ChannelPinned && updated.PinnedTag == "" {
+		fmt.Fprintln(c.stderr, "install: the pinned channel needs -tag")
+		return exitUsage
+	}
+	if dir != "" {
+		abs, _err := filepath.Abs(dir)
+		if _err != nil {
+			return c.fail(app.Debug.New(_err, debug.DataError, debug.ErrInstanceInvalid))
+		}
+		// The directory is replaced by the install, so it must not hold
+		// anything else.
+		if _err := archive.CheckDest(abs, configs.InstanceManifest); _err != nil {
+			fmt.Fprintf(c.stderr, "install: %v; pick an empty directory\n", _err)
+			return exitUsage
+		}
+		updated.Dir = abs
+	}
+	if kind != "" || binary != "" {
+		r := &runner.Runner{Kind: runner.Native}
+		if updated.Runner != nil {
+			copied := *updated.Runner
+			r = &copied
+		}
+		if kind != "" {
+			if !slices.Contains(runner.Kinds, runner.Kind(kind)) {
+				fmt.Fprintf(c.stderr, "install: unknown runner %q\n", kind)
+				return exitUsage
+			}
+			r.Kind = runner.Kind(kind)
+		}
+		if binary != "" {
+			abs, _err := filepath.Abs(binary)
+			if _err != nil {
+				return c.fail(app.Debug.New(_err, debug.DataError, debug.ErrInstanceInvalid))
+			}
+			r.Binary = abs
+		}
+		updated.Runner = r
+	}
+	if previous == nil {
+		if _, err := app.Data.AddInstance(app.Debug, name); err.Err != nil {
+			return c.fail(err)
+		}
+	}
+	if err := app.Data.SaveInstance(app.Debug, &updated); err.Err != nil {
+		c.restore(name, previous)
+		return c.fail(err)
+	}
+
+	if err := app.Install(name, githubClient, githubContext, c.progress(name)); err.Err != nil {
+		c.restore(name, previous)
+		return c.fail(err)
+	}
+	result := c.instance(name)
+	c.print(result, func(w io.Writer) {
+		fmt.Fprintf(w, "%s: installed %s in %s\n", name, result.Tag, result.Dir)
+	})
+	return exitOK
+}
+
+// restore puts back the settings instance name had before a failed
+// install, removing it when the install created it.
+func (c *cli) restore(name string, previous *data.Instance) {
+	var err *debug.Error
+	if previous != nil {
+		err = app.Data.SaveInstance(app.Debug, previous)
+	} else {
+		err = app.Data.RemoveInstance(app.Debug, name)
+	}
+	if err.Err != nil {
+		fmt.Fprintf(c.stderr, "p86l: restore the settings of %s: %v\n", name, err.Err)
+	}
+}
+
+func (c *cli) update(args []string) int {
+	var all bool
+	name, ok := c.flags("update", args, true, func(fs *flag.FlagSet) {
+		fs.BoolVar(&all, "all", false, "update every installed instance")
+	})
+	if !ok {
+		return exitUsage
+	}
+
+	names := []string{name}
+	if all {
+		names = nil
+		for _, instance := range app.Data.Instances() {
+			if app.IsInstalled(instance.Name) {
+				names = append(names, instance.Name)
+			}
+		}
+	}
+
+	type updateResult struct {
+		Name    string `json:"name"`
+		From    string `json:"from"`
+		To      string `json:"to"`
+		Updated bool   `json:"updated"`
+	}
+	var results []updateResult
+	for _, name := range names {
+		if !app.IsInstalled(name) {
+			return c.fail(app.Debug.New(fmt.Errorf("instance %s is not installed", name), debug.InstallError, debug.ErrInstanceNotFound))
+		}
+		instance := app.Data.Instance(name)
+		rel, err := app.ResolveRelease(instance, githubClient, githubContext)
+		if err.Err != nil {
+			return c.fail(err)
+		}
+		result := updateResult{Name: name, From: instance.Tag, To: rel.Tag}
+		if rel.Tag != instance.Tag {
+			if err := app.Install(name, githubClient, githubContext, c.progress(name)); err.Err != nil {
+				return c.fail(err)
+			}
+			result.Updated = true
+		}
+		results = append(results, result)
+	}
+	c.print(results, func(w io.Writer) {
+		for _, result := range results {
+			if result.Updated {
+				fmt.Fprintf(w, "%s: updated %s -> %s\n", result.Name, result.From, result.To)
+			} else {
+				fmt.Fprintf(w, "%s: up to date (%s)\n", result.Name, result.From)
+			}
+		}
+	})
+	return exitOK
+}
+
+func (c *cli) verify(args []string) int {
+	name, ok := c.flags("verify", args, true, nil)
+	if !ok {
+		return exitUsage
+	}
+	problems, err := app.Verify(name)
+	if err.Err != nil {
+		return c.fail(err)
+	}
+	if _, err := app.GameExecutable(name); err.Err != nil {
+		problems = append(problems, ESApp.Problem{Path: "executable", Reason: err.Err.Error()})
+	}
+	c.print(map[string]any{"name": name, "ok": len(problems) == 0, "problems": problems}, func(w io.Writer) {
+		for _, problem := range problems {
+			fmt.Fprintf(w, "%s: %s\n", problem.Path, problem.Reason)
+		}
+		if len(problems) == 0 {
+			fmt.Fprintf(w, "%s: ok\n", name)
+		}
+	})
+	if len(problems) > 0 {
+		return exitVerifyFailed
+	}
+	return exitOK
+}
+
+func (c *cli) launch(args []string) int {
+	var opts ESApp.LaunchOptions
+	var dryRun bool
+	name, ok := c.flags("launch", args, true, func(fs *flag.FlagSet) {
+		fs.BoolVar(&opts.SkipChecks, "force", false, "launch even when dependencies are missing")
+		fs.StringVar(&opts.Profile, "profile", "", "launch `profile` instead of the selected one")
+		fs.BoolVar(&dryRun, "dry-run", false, "print the command line without launching")
+	})
+	if !ok {
+		return exitUsage
+	}
+	if !app.IsInstalled(name) {
+		return c.fail(app.Debug.New(fmt.Errorf("instance %s is not installed", name), debug.LaunchError, debug.ErrGameNotFound))
+	}
+	if dryRun {
+		line, err := app.CommandLine(name, opts.Profile)
+		if err.Err != nil {
+			return c.fail(err)
+		}
+		c.print(map[string]any{"name": name, "command": line}, func(w io.Writer) {
+			fmt.Fprintln(w, line)
+		})
+		return exitOK
+	}
+	if err := app.LaunchWith(name, opts); err.Err != nil {
+		if missing := app.TakeMissingDependencies(); missing != nil && !c.json {
+			for _, m := range missing.Missing {
+				fmt.Fprintf(c.stderr, "missing %s\n", m)
+				if m.Hint != "" {
+					fmt.Fprintf(c.stderr, "    %s\n", m.Hint)
+				}
+			}
+		}
+		return c.fail(err)
+	}
+	c.print(map[string]any{"name": name, "launched": true}, func(w io.Writer) {
+		fmt.Fprintf(w, "%s: launched\n", name)
+	})
+	return exitOK
+}
+
+func (c *cli) list(args []string) int {
+	var check bool
+	if _, ok := c.flags("list", args, false, func(fs *flag.FlagSet) {
+		fs.BoolVar(&check, "check", false, "look up available updates")
+	}); !ok {
+		return exitUsage
+	}
+	if check {
+		if err := app.CheckUpdates(githubClient, githubContext); err.Err != nil {
+			return c.fail(err)
+		}
+	}
+
+	var results []cliInstance
+	for _, instance := range app.Data.Instances() {
+		results = append(results, c.instance(instance.Name))
+	}
+	c.print(results, func(w io.Writer) {
+		for _, result := range results {
+			state := "not installed"
+			if result.Installed {
+				state = result.Tag
+			}
+			if result.Update != "" {
+				state += ", update " + result.Update
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Name, result.Channel, state, result.Dir)
+		}
+	})
+	return exitOK
+}
+
+func (c *cli) changelog(args []string) int {
+	var offline bool
+	if _, ok := c.flags("changelog", args, false, func(fs *flag.FlagSet) {
+		fs.BoolVar(&offline, "offline", false, "print the cached changelog without checking for a new one")
+	}); !ok {
+		return exitUsage
+	}
+	cached := true
+	if !offline {
+		err := app.UpdateChangelog(githubClient, githubContext)
+		if err.Err != nil && app.Cache.Changelog == nil {
+			return c.fail(err)
+		}
+		cached = err.Err != nil
+	}
+	changelog := app.Cache.Changelog
+	if changelog == nil {
+		return c.fail(app.Debug.New(errors.New("no cached changelog"), debug.CacheError, debug.ErrChangelogLoad))
+	}
+	c.print(map[string]any{
+		"tag":        changelog.Tag,
+		"channel":    changelog.Channel,
+		"url":        changelog.URL,
+		"body":       changelog.Body,
+		"fetched_at": changelog.Timestamp,
+		"cached":     cached,
+	}, func(w io.Writer) {
+		if cached {
+			fmt.Fprintf(c.stderr, "Showing the changelog cached %s\n", changelog.Timestamp.Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintf(w, "%s\n%s\n\n%s\n", changelog.Tag, changelog.URL, changelog.Body)
+	})
+	return exitOK
+}
@@ -0,0 +1,131 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Command p86l-manifest signs launcher manifests.
+//
+//	p86l-manifest keygen                     print a new key pair
+//	p86l-manifest sign -key KEY manifest.json  print the signed manifest
+//	p86l-manifest verify -pub PUB signed.json  check a signed manifest
+//
+// Keys are base64. The public key goes into configs.ManifestPublicKey; the
+// private key is read from the file given to -key.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"p86l/internal/manifest"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	var err error
+	switch os.Args[1] {
+	case "keygen":
+		err = keygen()
+	case "sign":
+		err = sign(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: p86l-manifest keygen | sign -key FILE MANIFEST | verify -pub KEY SIGNED")
+	os.Exit(2)
+}
+
+func keygen() error {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("public:  %s\nprivate: %s\n", base64.StdEncoding.EncodeToString(public), base64.StdEncoding.EncodeToString(private))
+	return nil
+}
+
+func sign(args []string) error {
+	flags := flag.NewFlagSet("sign", flag.ExitOnError)
+	keyFile := flags.String("key", "", "file holding the base64 private key")
+	flags.Parse(args)
+	if *keyFile == "" || flags.NArg() != 1 {
+		usage()
+	}
+	encoded, err := os.ReadFile(*keyFile)
+	if err != nil {
+		return err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("%s does not hold a base64 ed25519 private key", *keyFile)
+	}
+	document, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	// Refuse documents the launcher could not read.
+	if err := json.Unmarshal(document, &manifest.Manifest{}); err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+	signed, err := manifest.Sign(document, ed25519.PrivateKey(key))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(string(signed))
+	return err
+}
+
+func verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	pub := flags.String("pub", "", "base64 public key")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		usage()
+	}
+	key, err := manifest.ParseKey(*pub)
+	if err != nil {
+		return err
+	}
+	signed, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	m, err := manifest.Verify(signed, key)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("manifest version %d is valid\n", m.Version)
+	return nil
+}
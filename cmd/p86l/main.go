@@ -22,12 +22,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"p86l"
 	"p86l/assets"
 	"p86l/configs"
 	"p86l/internal/debug"
+	"p86l/internal/selfupdate"
+	"p86l/internal/single"
 	"runtime"
 	"strings"
 
@@ -67,7 +70,59 @@ func init() {
 	}
 }
 
+// applyLauncherUpdate restarts into a staged launcher update, handing lock
+// over. It returns the lock to keep when the launcher keeps running.
+func applyLauncherUpdate(lock *single.Lock) *single.Lock {
+	if applied, err := selfupdate.Apply(); err != nil {
+		log.Error().Int("Code", debug.ErrLauncherUpdate).Str("Type", string(debug.AppError)).Err(err).Msg("Launcher update failed")
+	} else if applied {
+		log.Info().Msg("Launcher updated, restarting")
+		if err := selfupdate.Restart(func() {
+			if lock != nil {
+				lock.Close()
+			}
+		}); err != nil {
+			log.Error().Int("Code", debug.ErrLauncherUpdate).Str("Type", string(debug.AppError)).Err(err).Msg("Launcher restart failed")
+			if lock != nil {
+				lock, _ = single.Acquire(configs.AppName)
+			}
+		}
+	}
+	return lock
+}
+
 func main() {
+	// Commands leave launcher updates to the window so a restart cannot
+	// swallow their exit code.
+	args := os.Args[1:]
+	cli := p86l.IsCLI(args)
+
+	acquire := single.Acquire
+	if selfupdate.Restarted() {
+		// The launcher that restarted into this one may still be exiting.
+		acquire = func(name string) (*single.Lock, error) {
+			return single.AcquireWait(name, configs.RestartLockWait)
+		}
+	}
+	lock, _err := acquire(configs.AppName)
+	if errors.Is(_err, single.ErrLocked) {
+		if cli {
+			os.Exit(p86l.ForwardCLI(args))
+		}
+		if _err := single.Forward(configs.AppName, args); _err != nil {
+			log.Error().Err(_err).Msg("Forward to the running launcher failed")
+			os.Exit(1)
+		}
+		log.Info().Msg("Forwarded to the running launcher")
+		os.Exit(0)
+	} else if _err != nil {
+		log.Warn().Err(_err).Msg("Single instance lock unavailable")
+	}
+
+	if !cli {
+		lock = applyLauncherUpdate(lock)
+	}
+
 	appName := fmt.Sprintf("%s/%s", configs.CompanyName, configs.AppName)
 	if runtime.GOOS == "windows" {
 		appName = fmt.Sprintf("%s\\%s", configs.CompanyName, configs.AppName)
@@ -80,13 +135,22 @@ func main() {
 		log.Panic().Int("Code", debug.ErrGDataOpenFailed).Str("Type", string(debug.FSError)).Err(_err).Msg("GData panic")
 	}
 
+	if cli {
+		p86l.GDataM = m
+		code := p86l.RunCLI(args, lock)
+		if lock != nil {
+			lock.Close()
+		}
+		os.Exit(code)
+	}
+
 	iconImages, _err := assets.GetIconImages()
 	if _err != nil {
 		log.Panic().Int("Code", debug.ErrIconNotFound).Str("Type", string(debug.FSError)).Err(_err).Msg("Icons panic")
 	}
 
 	p86l.GDataM = m
-	if err := p86l.Run(); err.Err != nil {
+	if err := p86l.Run(args, lock); err.Err != nil {
 		log.Panic().Stack().Int("Code", p86l.AppErr.Code).Str("Type", string(p86l.AppErr.Type)).Err(p86l.AppErr.Err).Msg("Run panic")
 	}
 
@@ -98,7 +162,11 @@ func main() {
 		WindowMinWidth:  500,
 		WindowMinHeight: 280,
 	}
-	if _err = guigui.Run(&p86l.Root{}, op); _err != nil {
+	_err = guigui.Run(&p86l.Root{}, op)
+	if lock != nil {
+		lock.Close()
+	}
+	if _err != nil {
 		log.Error().Stack().Int("Code", p86l.AppErr.Code).Str("Type", string(p86l.AppErr.Type)).Err(p86l.AppErr.Err).Msg("App crashed")
 		os.Exit(1)
 	}
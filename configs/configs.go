@@ -21,6 +21,42 @@
 
 package configs
 
+import "time"
+
+// Link is a community link on Home. Icon names an embedded image in
+// assets and may be empty.
+type Link struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+	Icon  string `json:"icon,omitempty"`
+}
+
+// Features the launcher manifest can turn on or off.
+const (
+	FeatureNews            = "news"
+	FeatureRangedDownloads = "ranged_downloads"
+	FeatureDeepLinks       = "deep_links"
+)
+
+// AssetRule lists the asset name patterns, tried in order, that fit a
+// platform. An empty Arch matches every architecture. Assets matching any
+// of Excludes are skipped, so broad patterns leave other builds alone.
+type AssetRule struct {
+	OS       string   `json:"os"`
+	Arch     string   `json:"arch,omitempty"`
+	Patterns []string `json:"patterns"`
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+// Dependency is a shared library a Linux build of the game needs. Any of
+// Libraries satisfies it. Hints map a distribution ID from os-release,
+// also matched through ID_LIKE, to the command that installs it.
+type Dependency struct {
+	Name      string
+	Libraries []string
+	Hints     map[string]string
+}
+
 var (
 	CompanyName = "Project-86-Community"
 	AppName     = "Project-86-Launcher"
@@ -28,10 +64,209 @@ var (
 	RepoOwner = "Taliayaya"
 	RepoName  = "Project-86"
 
+	LauncherRepoOwner = "Project-86-Community"
+	LauncherRepoName  = "Project-86-Launcher"
+
+	// ManifestURL serves the launcher manifest, signed with the ed25519
+	// key whose base64 public half is ManifestPublicKey. It can move the
+	// game repository, require a newer launcher, show a banner, flip
+	// Features and withdraw releases. The values here apply until it has
+	// been fetched once.
+	//
+	// Both are empty for now, which turns the manifest off: nothing is
+	// fetched and the values here always apply. To turn it on, run
+	// "go run ./cmd/p86l-manifest keygen", keep the private key out of the
+	// repository, set ManifestPublicKey to the public key, publish the
+	// output of "p86l-manifest sign -key FILE manifest.json" and set
+	// ManifestURL to where it is served.
+	ManifestURL       = ""
+	ManifestPublicKey = ""
+	Features          = map[string]bool{
+		FeatureNews:            true,
+		FeatureRangedDownloads: true,
+		FeatureDeepLinks:       true,
+	}
+
+	// Links are shown on Home until the list at LinksURL, when set, has
+	// been fetched. Only http and https links are opened.
+	Links = []Link{
+		{Label: "Website", URL: "https://taliayaya.github.io/Project-86-Website/"},
+		{Label: "Github", URL: "https://github.com/Taliayaya/Project-86"},
+		{Label: "Discord", URL: "https://discord.gg/Yh2TQH97yA"},
+		{Label: "Patreon", URL: "https://patreon.com/project86"},
+	}
+	LinksURL = ""
+	MaxLinks = 8
+
+	// LinkScheme is the URL scheme of deep links, registered with the
+	// system through DesktopEntry on Linux and the registry on Windows.
+	LinkScheme   = "p86l"
+	DesktopEntry = "p86l.desktop"
+
 	Data          = "data"
 	ColorModeFile = "colormode.data"
 	AppScaleFile  = "appscale.data"
+	InstancesFile = "instances.json"
+	NetworkFile   = "network.json"
+	DownloadsFile = "downloads.json"
+	NewsFile      = "news.json"
+
+	DefaultInstance = "default"
+
+	// GitHubProbeURL is requested to check that GitHub is reachable. The
+	// rate limit endpoint does not count against the rate limit.
+	GitHubProbeURL = "https://api.github.com/rate_limit"
+	// FallbackProbeURLs tell a missing network apart from GitHub being
+	// blocked.
+	FallbackProbeURLs = []string{
+		"https://clients3.google.com/generate_204",
+		"https://www.cloudflare.com/cdn-cgi/trace",
+		"https://www.baidu.com",
+	}
+
+	// GitHubTokenKey names the personal access token in the secret store.
+	GitHubTokenKey = "github-token"
+	// GitHubTokenHosts are the only hosts the token is sent to.
+	GitHubTokenHosts = []string{"api.github.com"}
+
+	OnlineProbeInterval = time.Minute
+	OfflineMinBackoff   = 2 * time.Second
+	OfflineMaxBackoff   = time.Minute
 
 	Cache         = "cache"
 	ChangelogFile = "changelog.json"
+	MirrorsFile   = "mirrors.json"
+	LinksFile     = "links.json"
+	FeedFile      = "feed.json"
+	// LauncherManifestFile keeps the signed manifest as fetched.
+	LauncherManifestFile = "manifest.json"
+
+	// NewsFeedURL is the announcement feed on Home: JSON Feed, RSS or Atom.
+	// Settings can replace it, also with a local file. The fetched feed is
+	// kept for NewsExpiry, up to NewsMaxItems of which Home shows NewsShown.
+	NewsFeedURL  = ""
+	NewsExpiry   = time.Hour
+	NewsMaxItems = 20
+	NewsShown    = 4
+
+	// AssetMirrors are base URLs tried after the release source, each
+	// serving "<base>/<tag>/<asset name>". They are only used for assets
+	// with a known checksum.
+	AssetMirrors = []string{}
+	// A mirror slower than MirrorMinSpeed bytes per second over
+	// MirrorSlowWindow is dropped for the next one.
+	MirrorMinSpeed   int64 = 64 << 10
+	MirrorSlowWindow       = 20 * time.Second
+	// DownloadConnections is how many range requests an asset is fetched
+	// over by default, up to MaxDownloadConnections. Each range is at
+	// least MinDownloadPart bytes.
+	DownloadConnections          = 4
+	MaxDownloadConnections       = 16
+	MinDownloadPart        int64 = 8 << 20
+
+	// InstanceManifest, inside each instance, lists the installed files
+	// with their checksums for verification.
+	InstanceManifest = ".p86l-manifest.json"
+
+	Games     = "games"
+	Downloads = "downloads"
+	Logs      = "logs"
+	// Prefixes holds the Wine and Proton prefixes of the instances.
+	Prefixes = "prefixes"
+
+	// ExtractionOverhead is the extra space, as a multiple of the
+	// archive size, reserved for unpacking a downloaded release.
+	ExtractionOverhead = 2.0
+
+	// AssetRules picks the release asset for the running platform. Patterns
+	// are case-insensitive globs matched against the asset name. The
+	// launcher manifest can replace them.
+	AssetRules = []AssetRule{
+		{OS: "windows", Arch: "amd64", Patterns: []string{"*windows*x64*.zip", "*win64*.zip", "*windows*.zip"}, Excludes: []string{"*arm64*", "*aarch64*", "*win32*", "*x86.zip"}},
+		{OS: "windows", Arch: "arm64", Patterns: []string{"*windows*arm64*.zip"}},
+		{OS: "linux", Arch: "amd64", Patterns: []string{"*linux*x86_64*.appimage", "*linux*x64*.tar.gz", "*linux*.tar.gz", "*linux*.appimage", "*linux*.zip"}, Excludes: []string{"*arm64*", "*aarch64*"}},
+		{OS: "linux", Arch: "arm64", Patterns: []string{"*linux*aarch64*.appimage", "*linux*arm64*.tar.gz"}},
+		{OS: "darwin", Patterns: []string{"*mac*.zip", "*osx*.zip", "*darwin*.zip"}},
+	}
+
+	// LauncherAssetRules picks the launcher binary from its own releases.
+	LauncherAssetRules = []AssetRule{
+		{OS: "windows", Arch: "amd64", Patterns: []string{"*.exe"}},
+		{OS: "linux", Arch: "amd64", Patterns: []string{"*linux*amd64*", "*linux*x86_64*", "*linux*"}},
+		{OS: "linux", Arch: "arm64", Patterns: []string{"*linux*arm64*", "*linux*aarch64*"}},
+		{OS: "darwin", Patterns: []string{"*darwin*", "*mac*"}},
+	}
+
+	// GameExecutables are the patterns, tried in order, used to find the
+	// game inside an instance directory.
+	GameExecutables = map[string][]string{
+		"windows": {"Project-86.exe", "Project 86.exe", "*.exe"},
+		"linux":   {"Project-86.x86_64", "*.x86_64", "*.AppImage"},
+		"darwin":  {"*.app"},
+	}
+	GameExecutableExcludes = []string{"UnityCrashHandler*"}
+
+	// LinuxDependencies are checked before the game starts on Linux, with
+	// MinGlibc as the oldest C library the builds run on.
+	LinuxDependencies = []Dependency{
+		{
+			Name:      "Vulkan or OpenGL driver",
+			Libraries: []string{"libvulkan.so.1", "libGL.so.1"},
+			Hints: map[string]string{
+				"debian": "sudo apt install libvulkan1 mesa-vulkan-drivers",
+				"fedora": "sudo dnf install vulkan-loader mesa-vulkan-drivers",
+				"arch":   "sudo pacman -S vulkan-icd-loader",
+				"suse":   "sudo zypper install libvulkan1",
+			},
+		},
+		{
+			Name:      "X11 client library",
+			Libraries: []string{"libX11.so.6"},
+			Hints: map[string]string{
+				"debian": "sudo apt install libx11-6",
+				"fedora": "sudo dnf install libX11",
+				"arch":   "sudo pacman -S libx11",
+				"suse":   "sudo zypper install libX11-6",
+			},
+		},
+		{
+			Name:      "X11 cursor extension",
+			Libraries: []string{"libXcursor.so.1"},
+			Hints: map[string]string{
+				"debian": "sudo apt install libxcursor1",
+				"fedora": "sudo dnf install libXcursor",
+				"arch":   "sudo pacman -S libxcursor",
+				"suse":   "sudo zypper install libXcursor1",
+			},
+		},
+		{
+			Name:      "X11 display extension",
+			Libraries: []string{"libXrandr.so.2"},
+			Hints: map[string]string{
+				"debian": "sudo apt install libxrandr2",
+				"fedora": "sudo dnf install libXrandr",
+				"arch":   "sudo pacman -S libxrandr",
+				"suse":   "sudo zypper install libXrandr2",
+			},
+		},
+		{
+			Name:      "zlib",
+			Libraries: []string{"libz.so.1"},
+			Hints: map[string]string{
+				"debian": "sudo apt install zlib1g",
+				"fedora": "sudo dnf install zlib",
+				"arch":   "sudo pacman -S zlib",
+				"suse":   "sudo zypper install libz1",
+			},
+		},
+	}
+	MinGlibc = "2.17"
+
+	// LaunchHookTimeout bounds the pre-launch and post-exit hooks of launch
+	// profiles.
+	LaunchHookTimeout = 30 * time.Second
+
+	// RestartLockWait is how long a restarted launcher waits for the one
+	// it replaces to let go of the single instance lock.
+	RestartLockWait = 5 * time.Second
 )
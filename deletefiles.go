@@ -0,0 +1,248 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"fmt"
+	"image"
+	"p86l/internal/debug"
+	"p86l/internal/file"
+	"p86l/internal/widget"
+	"slices"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+	"github.com/rs/zerolog/log"
+)
+
+type deleteFilesItem struct {
+	category file.Category
+	text     basicwidget.Text
+	toggle   basicwidget.ToggleButton
+}
+
+type DeleteFilesPopup struct {
+	guigui.DefaultWidget
+
+	popup         basicwidget.Popup
+	titleText     basicwidget.Text
+	form          widget.Form
+	items         []*deleteFilesItem
+	keepLogText   basicwidget.Text
+	keepLogToggle basicwidget.ToggleButton
+	trashText     basicwidget.Text
+	trashToggle   basicwidget.ToggleButton
+	summaryText   basicwidget.Text
+	deleteButton  basicwidget.TextButton
+	cancelButton  basicwidget.TextButton
+
+	sizes     atomic.Pointer[[]int64]
+	onDeleted func(categories []file.Category)
+}
+
+func (d *DeleteFilesPopup) SetOnDeleted(f func(categories []file.Category)) {
+	d.onDeleted = f
+}
+
+func (d *DeleteFilesPopup) Open() {
+	if d.items == nil {
+		categories, err := app.FS.Categories(app.Debug)
+		if err.Err != nil {
+			app.Debug.SetToast(err)
+			return
+		}
+		for _, category := range categories {
+			item := &deleteFilesItem{category: category}
+			item.toggle.SetValue(true)
+			item.toggle.SetOnValueChanged(func(bool) {
+				d.replan()
+			})
+			d.items = append(d.items, item)
+		}
+		d.keepLogToggle.SetValue(true)
+		d.keepLogToggle.SetOnValueChanged(func(bool) {
+			d.replan()
+		})
+	}
+
+	d.sizes.Store(nil)
+	d.replan()
+	d.popup.Open()
+}
+
+// options reads the toggles, so it must be called on the UI thread.
+func (d *DeleteFilesPopup) options(dryRun bool) file.ClearOptions {
+	opts := file.ClearOptions{
+		Trash:  file.CanTrash() && d.trashToggle.Value(),
+		DryRun: dryRun,
+	}
+	if d.keepLogToggle.Value() && TheDebugMode.LogFile != nil {
+		opts.Skip = append(opts.Skip, TheDebugMode.LogFile.Name())
+	}
+	return opts
+}
+
+func (d *DeleteFilesPopup) categories(selectedOnly bool) []file.Category {
+	var categories []file.Category
+	for _, item := range d.items {
+		if !selectedOnly || item.toggle.Value() {
+			categories = append(categories, item.category)
+		}
+	}
+	return categories
+}
+
+func (d *DeleteFilesPopup) replan() {
+	go d.plan(d.categories(false), d.options(true))
+}
+
+func clearCategory(category file.Category, opts file.ClearOptions) (int64, *debug.Error) {
+	opts.Skip = append(slices.Clone(opts.Skip), category.Keep...)
+	return app.FS.ClearFolder(category.Dir, opts, app.Debug)
+}
+
+// plan does a dry run over every category to show what would be removed.
+func (d *DeleteFilesPopup) plan(categories []file.Category, opts file.ClearOptions) {
+	sizes := make([]int64, len(categories))
+	for i, category := range categories {
+		size, err := clearCategory(category, opts)
+		if err.Err != nil {
+			app.Debug.SetToast(err)
+			return
+		}
+		sizes[i] = size
+	}
+	d.sizes.Store(&sizes)
+}
+
+func (d *DeleteFilesPopup) delete() {
+	categories := d.categories(true)
+	opts := d.options(false)
+	d.popup.Close()
+	if d.onDeleted != nil {
+		d.onDeleted(categories)
+	}
+
+	go func() {
+		defer app.FilesChanged()
+		var freed int64
+		for _, category := range categories {
+			size, err := clearCategory(category, opts)
+			freed += size
+			if err.Err != nil {
+				app.Debug.SetToast(err)
+				return
+			}
+		}
+		log.Info().Int64("Freed", freed).Bool("Trash", opts.Trash).Msg("Delete files")
+	}()
+}
+
+func (d *DeleteFilesPopup) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppender) {
+	u := float64(basicwidget.UnitSize(context))
+
+	sizes := d.sizes.Load()
+	var total int64
+	formItems := make([]*widget.FormItem, 0, len(d.items)+2)
+	for i, item := range d.items {
+		if sizes != nil {
+			item.text.SetText(fmt.Sprintf("%s (%s)", item.category.Name, file.FormatSize((*sizes)[i])))
+			if item.toggle.Value() {
+				total += (*sizes)[i]
+			}
+		} else {
+			item.text.SetText(item.category.Name)
+		}
+		formItems = append(formItems, &widget.FormItem{PrimaryWidget: &item.text, SecondaryWidget: &item.toggle})
+	}
+	d.keepLogText.SetText("Keep current log file")
+	formItems = append(formItems, &widget.FormItem{PrimaryWidget: &d.keepLogText, SecondaryWidget: &d.keepLogToggle})
+	if file.CanTrash() {
+		d.trashText.SetText("Move to trash")
+		formItems = append(formItems, &widget.FormItem{PrimaryWidget: &d.trashText, SecondaryWidget: &d.trashToggle})
+	}
+	d.form.SetItems(formItems)
+
+	d.titleText.SetText("Delete files")
+	d.titleText.SetBold(true)
+	if sizes != nil {
+		d.summaryText.SetText(fmt.Sprintf("%s will be removed", file.FormatSize(total)))
+	} else {
+		d.summaryText.SetText("Calculating...")
+	}
+
+	d.deleteButton.SetText("Delete")
+	d.deleteButton.SetOnUp(d.delete)
+	if sizes != nil {
+		guigui.Enable(&d.deleteButton)
+	} else {
+		guigui.Disable(&d.deleteButton)
+	}
+	d.cancelButton.SetText("Cancel")
+	d.cancelButton.SetOnUp(func() {
+		d.popup.Close()
+	})
+
+	contentWidth := int(14 * u)
+	_, formHeight := d.form.Size(context)
+	contentHeight := formHeight + int(5*u)
+	guigui.SetPosition(&d.popup, image.Pt(0, 0))
+	bounds := guigui.Bounds(&d.popup)
+	contentPosition := image.Point{
+		X: bounds.Min.X + (bounds.Dx()-contentWidth)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-contentHeight)/2,
+	}
+	contentBounds := image.Rectangle{
+		Min: contentPosition,
+		Max: contentPosition.Add(image.Pt(contentWidth, contentHeight)),
+	}
+	d.popup.SetContent(func(context *guigui.Context, appender *basicwidget.ContainerChildWidgetAppender) {
+		pt := contentBounds.Min.Add(image.Pt(int(0.5*u), int(0.5*u)))
+		guigui.SetPosition(&d.titleText, pt)
+		appender.AppendChildWidget(&d.titleText)
+
+		pt.Y += int(1 * u)
+		d.form.SetWidth(context, contentWidth-int(1*u))
+		guigui.SetPosition(&d.form, pt)
+		appender.AppendChildWidget(&d.form)
+
+		pt.Y += formHeight + int(0.5*u)
+		guigui.SetPosition(&d.summaryText, pt)
+		appender.AppendChildWidget(&d.summaryText)
+
+		w, h := d.deleteButton.Size(context)
+		pt = contentBounds.Max.Add(image.Pt(-int(0.5*u)-w, -int(0.5*u)-h))
+		guigui.SetPosition(&d.deleteButton, pt)
+		appender.AppendChildWidget(&d.deleteButton)
+
+		w, _ = d.cancelButton.Size(context)
+		pt.X -= w + int(0.5*u)
+		guigui.SetPosition(&d.cancelButton, pt)
+		appender.AppendChildWidget(&d.cancelButton)
+	})
+	d.popup.SetContentBounds(contentBounds)
+	d.popup.SetBackgroundBlurred(true)
+	d.popup.SetCloseByClickingOutside(false)
+
+	appender.AppendChildWidget(&d.popup)
+}
@@ -0,0 +1,128 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"fmt"
+	"image"
+	ESApp "p86l/internal/app"
+	"strings"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+// DependenciesPopup lists the libraries a launch stopped on, with the
+// commands that install them.
+type DependenciesPopup struct {
+	guigui.DefaultWidget
+
+	popup        basicwidget.Popup
+	titleText    basicwidget.Text
+	missingText  basicwidget.Text
+	launchButton basicwidget.TextButton
+	closeButton  basicwidget.TextButton
+
+	missing *ESApp.MissingDependencies
+}
+
+func (d *DependenciesPopup) Open(missing *ESApp.MissingDependencies) {
+	d.missing = missing
+	d.popup.Open()
+}
+
+func (d *DependenciesPopup) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppender) {
+	u := float64(basicwidget.UnitSize(context))
+	contentWidth := int(16 * u)
+
+	d.titleText.SetText("Missing dependencies")
+	d.titleText.SetBold(true)
+
+	var lines []string
+	if d.missing != nil {
+		for _, m := range d.missing.Missing {
+			lines = append(lines, fmt.Sprintf("%s: %s", m.Dependency.Name, strings.Join(m.Dependency.Libraries, " or ")))
+			if m.Hint != "" {
+				lines = append(lines, "    "+m.Hint)
+			}
+		}
+	}
+	d.missingText.SetMultiline(true)
+	d.missingText.SetText(WrapText(context, strings.Join(lines, "\n"), contentWidth-int(1*u)))
+
+	d.launchButton.SetText("Launch anyway")
+	d.launchButton.SetOnUp(func() {
+		d.popup.Close()
+		if d.missing == nil {
+			return
+		}
+		name, opts := d.missing.Instance, d.missing.Options
+		opts.SkipChecks = true
+		go func() {
+			if err := app.LaunchWith(name, opts); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+		}()
+	})
+	d.closeButton.SetText("Close")
+	d.closeButton.SetOnUp(func() {
+		d.popup.Close()
+	})
+
+	d.missingText.SetWidth(contentWidth - int(1*u))
+	_, textHeight := d.missingText.Size(context)
+	contentHeight := textHeight + int(4*u)
+	guigui.SetPosition(&d.popup, image.Pt(0, 0))
+	bounds := guigui.Bounds(&d.popup)
+	contentPosition := image.Point{
+		X: bounds.Min.X + (bounds.Dx()-contentWidth)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-contentHeight)/2,
+	}
+	contentBounds := image.Rectangle{
+		Min: contentPosition,
+		Max: contentPosition.Add(image.Pt(contentWidth, contentHeight)),
+	}
+	d.popup.SetContent(func(context *guigui.Context, appender *basicwidget.ContainerChildWidgetAppender) {
+		pt := contentBounds.Min.Add(image.Pt(int(0.5*u), int(0.5*u)))
+		guigui.SetPosition(&d.titleText, pt)
+		appender.AppendChildWidget(&d.titleText)
+
+		pt.Y += int(1 * u)
+		guigui.SetPosition(&d.missingText, pt)
+		appender.AppendChildWidget(&d.missingText)
+
+		w, h := d.launchButton.Size(context)
+		pt = contentBounds.Max.Add(image.Pt(-int(0.5*u)-w, -int(0.5*u)-h))
+		guigui.SetPosition(&d.launchButton, pt)
+		appender.AppendChildWidget(&d.launchButton)
+
+		w, _ = d.closeButton.Size(context)
+		pt.X -= w + int(0.5*u)
+		guigui.SetPosition(&d.closeButton, pt)
+		appender.AppendChildWidget(&d.closeButton)
+	})
+	d.popup.SetContentBounds(contentBounds)
+	d.popup.SetBackgroundBlurred(true)
+	d.popup.SetCloseByClickingOutside(false)
+
+	appender.AppendChildWidget(&d.popup)
+}
@@ -3,7 +3,9 @@ module p86l
 go 1.23.6
 
 require (
+	github.com/Microsoft/go-winio v0.6.2
 	github.com/biessek/golang-ico v0.0.0-20180326222316-d348d9ea4670
+	github.com/godbus/dbus/v5 v5.1.0
 	github.com/google/go-github/v69 v69.2.0
 	github.com/hajimehoshi/ebiten/v2 v2.9.0-alpha.4.0.20250331150732-cbdf0c8f4bf0
 	github.com/hajimehoshi/guigui v0.0.0-20250326181936-e1240a907620
@@ -12,6 +14,8 @@ require (
 	github.com/quasilyte/gdata/v2 v2.0.0
 	github.com/rs/zerolog v1.33.0
 	github.com/skratchdot/open-golang v0.0.0-20200116055534-eef842397966
+	golang.org/x/sync v0.12.0
+	golang.org/x/sys v0.31.0
 )
 
 require (
@@ -29,8 +33,6 @@ require (
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	golang.org/x/image v0.25.0 // indirect
-	golang.org/x/sync v0.12.0 // indirect
-	golang.org/x/sys v0.31.0 // indirect
 	golang.org/x/text v0.23.0 // indirect
 	howett.net/plist v1.0.1 // indirect
 )
@@ -22,10 +22,15 @@
 package p86l
 
 import (
+	"fmt"
 	"image"
 	"p86l/assets"
+	"p86l/configs"
+	ESApp "p86l/internal/app"
 	"p86l/internal/debug"
 	"p86l/internal/widget"
+	"slices"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/guigui"
 	"github.com/hajimehoshi/guigui/basicwidget"
@@ -40,19 +45,33 @@ type Home struct {
 	mdLayoutForm    widget.Form
 	mdLayoutVLayout widget.VerticalLayout
 
-	bannerImage basicwidget.Image
-	titleText   basicwidget.Text
-	gameButton  basicwidget.TextButton
+	announcementText   basicwidget.Text
+	announcementButton basicwidget.TextButton
+	outdatedText       basicwidget.Text
+	launcherButton     basicwidget.TextButton
+	updatingLauncher   atomic.Bool
 
-	form          basicwidget.Form
-	websiteButton basicwidget.TextButton
-	githubButton  basicwidget.TextButton
-	discordButton basicwidget.TextButton
-	patreonButton basicwidget.TextButton
+	bannerImage  basicwidget.Image
+	titleText    basicwidget.Text
+	gameButton   basicwidget.TextButton
+	updateButton basicwidget.TextButton
+	// profileDropdownList picks the launch profile of the game button.
+	profileDropdownList basicwidget.DropdownList
+
+	form        basicwidget.Form
+	linkButtons []basicwidget.TextButton
+	news        News
 
 	err *debug.Error
 }
 
+// showUpdateButton leaves out updates this launcher cannot install; the
+// launcher update notice takes their place.
+func (h *Home) showUpdateButton() bool {
+	return !app.IsEnqueued(configs.DefaultInstance) && app.IsInternet() && app.UpdateAvailable(configs.DefaultInstance) != nil &&
+		app.RequiredLauncherFor(configs.DefaultInstance) == ""
+}
+
 func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppender) {
 	img, err := assets.TheImageCache.Get("banner")
 	if err != nil {
@@ -61,34 +80,53 @@ func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 	}
 	h.bannerImage.SetImage(img)
 
-	h.websiteButton.SetOnDown(func() {
-		// go func() {
-		// 	if err := browser.OpenURL("https://taliayaya.github.io/Project-86-Website/"); err != nil {
-		// 		app.PopError(errors.New(err.Error()))
-		// 	}
-		// }()
-	})
-	h.githubButton.SetOnDown(func() {
-		// go func() {
-		// 	if err := browser.OpenURL("https://github.com/Taliayaya/Project-86"); err != nil {
-		// 		app.PopError(errors.New(err.Error()))
-		// 	}
-		// }()
-	})
-	h.discordButton.SetOnDown(func() {
-		// go func() {
-		// 	if err := browser.OpenURL("https://discord.gg/Yh2TQH97yA"); err != nil {
-		// 		app.PopError(errors.New(err.Error()))
-		// 	}
-		// }()
+	h.gameButton.SetOnDown(func() {
+		if app.IsInstalled(configs.DefaultInstance) && !app.IsEnqueued(configs.DefaultInstance) {
+			// A pre-launch hook may take a while.
+			go func() {
+				if err := app.Launch(configs.DefaultInstance); err.Err != nil {
+					app.Debug.SetToast(err)
+				}
+			}()
+			return
+		}
+		if err := app.Enqueue(configs.DefaultInstance); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
 	})
-	h.patreonButton.SetOnDown(func() {
-		// go func() {
-		// 	if err := browser.OpenURL("https://patreon.com/project86"); err != nil {
-		// 		app.PopError(errors.New(err.Error()))
-		// 	}
-		// }()
+	if instance := app.Data.Instance(configs.DefaultInstance); instance != nil {
+		names := profileNames(instance)
+		h.profileDropdownList.SetItemsByStrings(names)
+		h.profileDropdownList.SetSelectedItemIndex(max(0, slices.Index(names[1:], instance.Profile)+1))
+		h.profileDropdownList.SetOnValueChanged(func(index int) {
+			selectProfile(instance, index)
+		})
+	}
+	h.updateButton.SetOnDown(func() {
+		if err := app.Enqueue(configs.DefaultInstance); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
 	})
+	links := app.Links()
+	if len(h.linkButtons) != len(links) {
+		h.linkButtons = make([]basicwidget.TextButton, len(links))
+	}
+	for i, link := range links {
+		h.linkButtons[i].SetText(link.Label)
+		h.linkButtons[i].SetImage(nil)
+		if link.Icon != "" {
+			if icon, err := assets.TheImageCache.Get(link.Icon); err == nil {
+				h.linkButtons[i].SetImage(icon)
+			}
+		}
+		h.linkButtons[i].SetOnDown(func() {
+			if err := ESApp.ValidLink(link); err != nil {
+				app.Debug.SetToast(app.Debug.New(err, debug.AppError, debug.ErrBrowserOpen))
+				return
+			}
+			openURL(link.URL)
+		})
+	}
 
 	u := float64(basicwidget.UnitSize(context))
 	w, _h := h.Size(context)
@@ -109,29 +147,59 @@ func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 	h.titleText.SetText("Welcome to Project 86")
 	h.titleText.SetHorizontalAlign(basicwidget.HorizontalAlignCenter)
 
-	h.websiteButton.SetText("Website")
-	h.websiteButton.SetWidth(int(float64(w)/4) - int(1*u))
-
-	h.githubButton.SetText("Github")
-	h.githubButton.SetWidth(int(float64(w)/4) - int(1*u))
-
-	h.discordButton.SetText("Discord")
-	h.discordButton.SetWidth(int(float64(w)/4) - int(1*u))
-
-	h.patreonButton.SetText("Patreon")
-	h.patreonButton.SetWidth(int(float64(w)/4) - int(1*u))
-
+	h.setLinkWidth(int(float64(w)/4) - int(1*u))
 	h.form.SetWidth(context, int(float64(w)/2)-int(0.5*u))
-	h.form.SetItems([]*basicwidget.FormItem{
-		{
-			PrimaryWidget:   &h.websiteButton,
-			SecondaryWidget: &h.githubButton,
-		},
-		{
-			PrimaryWidget:   &h.discordButton,
-			SecondaryWidget: &h.patreonButton,
-		},
-	})
+	// Two links per row. The form needs a secondary widget, so an odd last
+	// link goes on its own.
+	var formItems []*basicwidget.FormItem
+	for i := 0; i < len(h.linkButtons); i += 2 {
+		item := &basicwidget.FormItem{SecondaryWidget: &h.linkButtons[i]}
+		if i+1 < len(h.linkButtons) {
+			item.PrimaryWidget, item.SecondaryWidget = &h.linkButtons[i], &h.linkButtons[i+1]
+		}
+		formItems = append(formItems, item)
+	}
+	h.form.SetItems(formItems)
+
+	if banner := app.Banner(); banner != nil {
+		h.announcementText.SetMultiline(true)
+		h.announcementText.SetHorizontalAlign(basicwidget.HorizontalAlignCenter)
+		h.announcementText.SetText(WrapText(context, banner.Text, w-int(2*u)))
+		h.announcementButton.SetText(banner.Text)
+		h.announcementButton.SetOnDown(func() {
+			if err := ESApp.ValidLink(configs.Link{Label: banner.Text, URL: banner.URL}); err != nil {
+				app.Debug.SetToast(app.Debug.New(err, debug.AppError, debug.ErrBrowserOpen))
+				return
+			}
+			openURL(banner.URL)
+		})
+	}
+	if required := app.RequiredLauncherFor(configs.DefaultInstance); required != "" {
+		h.outdatedText.SetMultiline(true)
+		h.outdatedText.SetHorizontalAlign(basicwidget.HorizontalAlignCenter)
+		h.outdatedText.SetText(WrapText(context, fmt.Sprintf("Launcher %s or newer is required to install the game.", required), w-int(2*u)))
+		if h.updatingLauncher.Load() {
+			h.launcherButton.SetText("Updating launcher...")
+			guigui.Disable(&h.launcherButton)
+		} else {
+			h.launcherButton.SetText("Update launcher")
+			guigui.Enable(&h.launcherButton)
+		}
+		h.launcherButton.SetOnDown(func() {
+			if !h.updatingLauncher.CompareAndSwap(false, true) {
+				return
+			}
+			go func() {
+				defer h.updatingLauncher.Store(false)
+				if err := app.UpdateLauncherTo(githubClient, githubContext, required, nil); err.Err != nil {
+					app.Debug.SetToast(err)
+				}
+			}()
+		})
+	}
+
+	h.news.SetItems(context, app.News())
+	h.news.SetWidth(context, w-int(2*u))
 
 	h.vLayout.SetHorizontalAlign(widget.HorizontalAlignCenter)
 	h.vLayout.SetBackground(false)
@@ -141,12 +209,21 @@ func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 	h.vLayout.SetWidth(context, w-int(1*u))
 	guigui.SetPosition(&h.vLayout, pt)
 
-	if app.IsInternet() {
+	if app.IsEnqueued(configs.DefaultInstance) {
+		h.gameButton.SetText(downloadStateText(configs.DefaultInstance))
+		guigui.Disable(&h.gameButton)
+	} else if app.IsInstalled(configs.DefaultInstance) {
+		h.gameButton.SetText("Play")
+		guigui.Enable(&h.gameButton)
+	} else if app.IsInternet() {
 		h.gameButton.SetText("Install")
 		guigui.Enable(&h.gameButton)
 	} else {
-		h.gameButton.SetText("NO INTERNET")
-		guigui.Disable(&h.gameButton)
+		h.gameButton.SetText(fmt.Sprintf("%s - install when online", networkStatusText()))
+		guigui.Enable(&h.gameButton)
+	}
+	if update := app.UpdateAvailable(configs.DefaultInstance); update != nil {
+		h.updateButton.SetText(fmt.Sprintf("Update to %s", update.Tag))
 	}
 
 	if w >= int(940*context.AppScale()) {
@@ -161,15 +238,13 @@ func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 			h.bannerImage.SetSize(context, int(float64(newWidth)/1.8), int(float64(newHeight)/1.8))
 		}
 		h.gameButton.SetWidth(int(float64(w)/2.5) - int(1*u))
+		h.updateButton.SetWidth(int(float64(w)/2.5) - int(1*u))
 		h.titleText.ResetSize()
 		h.titleText.SetHorizontalAlign(basicwidget.HorizontalAlignCenter)
 
 		h.titleText.SetScale(2.8)
 
-		h.websiteButton.SetWidth(int(float64(w)/5) - int(1*u))
-		h.githubButton.SetWidth(int(float64(w)/5) - int(1*u))
-		h.discordButton.SetWidth(int(float64(w)/5) - int(1*u))
-		h.patreonButton.SetWidth(int(float64(w)/5) - int(1*u))
+		h.setLinkWidth(int(float64(w)/5) - int(1*u))
 		h.form.SetWidth(context, int(float64(w)/2.5)-int(0.5*u))
 
 		h.mdLayoutVLayout.SetHorizontalAlign(widget.HorizontalAlignCenter)
@@ -178,24 +253,30 @@ func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 		h.mdLayoutVLayout.SetBorder(false)
 
 		h.mdLayoutVLayout.SetWidth(context, int(float64(w)/2.2)-int(2*u))
-		h.mdLayoutVLayout.SetItems([]*widget.LayoutItem{
-			{Widget: &h.titleText},
-			{Widget: &h.gameButton},
-			{Widget: &h.form},
-		})
+		h.mdLayoutVLayout.SetItems(slices.Concat(
+			[]*widget.LayoutItem{{Widget: &h.titleText}},
+			h.gameItems(),
+			[]*widget.LayoutItem{{Widget: &h.form}},
+		))
 
 		h.mdLayoutForm.SetWidth(context, w-int(1*u))
 		h.mdLayoutForm.SetItems([]*widget.FormItem{
 			{PrimaryWidget: &h.bannerImage, SecondaryWidget: &h.mdLayoutVLayout},
 		})
 
+		h.vLayout.SetItems(slices.Concat(
+			h.noticeItems(),
+			[]*widget.LayoutItem{{Widget: &h.mdLayoutForm}},
+			h.newsItems(),
+		))
+		// Keep the form centered, unless the news would not fit below it.
 		_, mdLayoutFormHeight := h.mdLayoutForm.Size(context)
-		guigui.SetPosition(&h.vLayout, image.Pt(pt.X, pt.Y+(_h/2-int(float64(mdLayoutFormHeight)/1.5))))
-		h.vLayout.SetItems([]*widget.LayoutItem{
-			{Widget: &h.mdLayoutForm},
-		})
+		_, vLayoutHeight := h.vLayout.Size(context)
+		offset := max(0, min(_h/2-int(float64(mdLayoutFormHeight)/1.5), _h-vLayoutHeight-int(u)))
+		guigui.SetPosition(&h.vLayout, image.Pt(pt.X, pt.Y+offset))
 	} else if w >= int(640*context.AppScale()) {
 		h.gameButton.SetWidth(int(float64(w)/2.3) - int(1*u))
+		h.updateButton.SetWidth(int(float64(w)/2.3) - int(1*u))
 		h.titleText.SetWidth(int(float64(w)/2.3) - int(1*u))
 		h.titleText.SetHorizontalAlign(basicwidget.HorizontalAlignCenter)
 
@@ -206,10 +287,10 @@ func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 		h.smLayoutVLayout.SetBorder(false)
 
 		h.smLayoutVLayout.SetWidth(context, w/2-int(2*u))
-		h.smLayoutVLayout.SetItems([]*widget.LayoutItem{
-			{Widget: &h.titleText},
-			{Widget: &h.gameButton},
-		})
+		h.smLayoutVLayout.SetItems(slices.Concat(
+			[]*widget.LayoutItem{{Widget: &h.titleText}},
+			h.gameItems(),
+		))
 
 		h.smLayoutForm.SetWidth(context, w-int(1*u))
 		h.smLayoutForm.SetItems([]*widget.FormItem{
@@ -219,26 +300,71 @@ func (h *Home) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 			},
 		})
 
-		h.vLayout.SetItems([]*widget.LayoutItem{
-			{Widget: &h.bannerImage},
-			{Widget: &h.smLayoutForm},
-		})
+		h.vLayout.SetItems(slices.Concat(
+			h.noticeItems(),
+			[]*widget.LayoutItem{{Widget: &h.bannerImage}, {Widget: &h.smLayoutForm}},
+			h.newsItems(),
+		))
 	} else {
 		h.gameButton.SetWidth(int(float64(w)/1.5) - int(1*u))
+		h.updateButton.SetWidth(int(float64(w)/1.5) - int(1*u))
 		h.titleText.ResetSize()
 
 		h.titleText.SetScale(2)
 
-		h.vLayout.SetItems([]*widget.LayoutItem{
-			{Widget: &h.bannerImage},
-			{Widget: &h.titleText},
-			{Widget: &h.gameButton},
-			{Widget: &h.form},
-		})
+		h.vLayout.SetItems(slices.Concat(
+			h.noticeItems(),
+			[]*widget.LayoutItem{{Widget: &h.bannerImage}, {Widget: &h.titleText}},
+			h.gameItems(),
+			[]*widget.LayoutItem{{Widget: &h.form}},
+			h.newsItems(),
+		))
 	}
 	appender.AppendChildWidget(&h.vLayout)
 }
 
+func (h *Home) setLinkWidth(width int) {
+	for i := range h.linkButtons {
+		h.linkButtons[i].SetWidth(width)
+	}
+}
+
+func (h *Home) gameItems() []*widget.LayoutItem {
+	items := []*widget.LayoutItem{{Widget: &h.gameButton}}
+	if instance := app.Data.Instance(configs.DefaultInstance); instance != nil && len(instance.Profiles) > 0 &&
+		app.IsInstalled(configs.DefaultInstance) {
+		items = append(items, &widget.LayoutItem{Widget: &h.profileDropdownList})
+	}
+	if h.showUpdateButton() {
+		items = append(items, &widget.LayoutItem{Widget: &h.updateButton})
+	}
+	return items
+}
+
+// noticeItems returns the announcement of the launcher manifest and the
+// notice of an outdated launcher with its update button, when there are
+// any.
+func (h *Home) noticeItems() []*widget.LayoutItem {
+	var items []*widget.LayoutItem
+	if banner := app.Banner(); banner != nil && banner.URL != "" {
+		items = append(items, &widget.LayoutItem{Widget: &h.announcementButton})
+	} else if banner != nil {
+		items = append(items, &widget.LayoutItem{Widget: &h.announcementText})
+	}
+	if app.RequiredLauncherFor(configs.DefaultInstance) != "" {
+		items = append(items, &widget.LayoutItem{Widget: &h.outdatedText}, &widget.LayoutItem{Widget: &h.launcherButton})
+	}
+	return items
+}
+
+// newsItems returns the news list, when the feed has items.
+func (h *Home) newsItems() []*widget.LayoutItem {
+	if len(app.News()) == 0 {
+		return nil
+	}
+	return []*widget.LayoutItem{{Widget: &h.news}}
+}
+
 func (h *Home) Update(context *guigui.Context) error {
 	if h.err != nil && h.err.Err != nil {
 		AppErr = h.err
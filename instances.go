@@ -0,0 +1,409 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"fmt"
+	"image"
+	"p86l/configs"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/release"
+	"p86l/internal/runner"
+	"p86l/internal/widget"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+type Instances struct {
+	guigui.DefaultWidget
+
+	vLayout      widget.VerticalLayout
+	newForm      widget.Form
+	channelForm  widget.Form
+	pinnedForm   widget.Form
+	instanceText basicwidget.Text
+
+	instanceDropdownList basicwidget.DropdownList
+	newInstanceField     basicwidget.TextField
+	addInstanceButton    basicwidget.TextButton
+	channelText          basicwidget.Text
+	channelDropdownList  basicwidget.DropdownList
+	pinnedTagText        basicwidget.Text
+	pinnedTagField       basicwidget.TextField
+	statusText           basicwidget.Text
+	installButton        basicwidget.TextButton
+
+	runnerForm         widget.Form
+	runnerText         basicwidget.Text
+	runnerDropdownList basicwidget.DropdownList
+	binaryForm         widget.Form
+	binaryText         basicwidget.Text
+	binaryField        basicwidget.TextField
+	prefixForm         widget.Form
+	prefixText         basicwidget.Text
+	prefixField        basicwidget.TextField
+	steamForm          widget.Form
+	steamText          basicwidget.Text
+	steamField         basicwidget.TextField
+	envForm            widget.Form
+	envText            basicwidget.Text
+	envField           basicwidget.TextField
+	profiles           launchProfiles
+
+	selected int
+	synced   string
+}
+
+// instance returns a copy of the selected instance.
+func (i *Instances) instance() *data.Instance {
+	instances := app.Data.Instances()
+	if i.selected < 0 || i.selected >= len(instances) {
+		i.selected = 0
+	}
+	if len(instances) == 0 {
+		return nil
+	}
+	return instances[i.selected]
+}
+
+// Select shows the instance called name.
+func (i *Instances) Select(name string) {
+	for index, instance := range app.Data.Instances() {
+		if instance.Name == name {
+			i.selected = index
+			i.synced = ""
+		}
+	}
+}
+
+// refreshReleases checks the instance channel again after it changed, or
+// once the launcher is online again.
+func (i *Instances) refreshReleases(name string) {
+	app.Queue("releases:"+name, func() {
+		if name == configs.DefaultInstance {
+			if err := app.UpdateChangelog(githubClient, githubContext); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+		}
+		if err := app.CheckUpdates(githubClient, githubContext); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+}
+
+func (i *Instances) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppender) {
+	instance := i.instance()
+	if instance == nil {
+		return
+	}
+
+	var names []string
+	for _, item := range app.Data.Instances() {
+		names = append(names, item.Name)
+	}
+	i.instanceDropdownList.SetItemsByStrings(names)
+	i.instanceDropdownList.SetSelectedItemIndex(i.selected)
+
+	var channels []string
+	for _, channel := range release.Channels {
+		channels = append(channels, strings.ToUpper(string(channel[:1]))+string(channel[1:]))
+	}
+	i.channelDropdownList.SetItemsByStrings(channels)
+
+	// Copy the stored settings into the widgets whenever another instance
+	// gets selected.
+	if i.synced != instance.Name {
+		i.synced = instance.Name
+		channelIndex := 0
+		for index, channel := range release.Channels {
+			if channel == instance.Channel {
+				channelIndex = index
+			}
+		}
+		i.channelDropdownList.SetSelectedItemIndex(channelIndex)
+		i.pinnedTagField.SetText(instance.PinnedTag)
+
+		r := instance.Runner
+		i.runnerDropdownList.SetSelectedItemIndex(max(0, slices.Index(runner.Kinds, runner.Native)))
+		i.binaryField.SetText("")
+		i.prefixField.SetText("")
+		i.steamField.SetText("")
+		i.envField.SetText("")
+		if r != nil {
+			if index := slices.Index(runner.Kinds, r.Kind); index >= 0 {
+				i.runnerDropdownList.SetSelectedItemIndex(index)
+			}
+			i.binaryField.SetText(r.Binary)
+			i.prefixField.SetText(r.Prefix)
+			i.steamField.SetText(r.Steam)
+			i.envField.SetText(runner.FormatEnv(r.Env))
+		}
+	}
+
+	i.instanceDropdownList.SetOnValueChanged(func(index int) {
+		i.selected = index
+	})
+
+	i.addInstanceButton.SetOnDown(func() {
+		if _, err := app.Data.AddInstance(app.Debug, strings.TrimSpace(i.newInstanceField.Text())); err.Err != nil {
+			app.Debug.SetToast(err)
+			return
+		}
+		i.newInstanceField.SetText("")
+		i.selected = len(app.Data.Instances()) - 1
+		i.synced = ""
+	})
+
+	i.channelDropdownList.SetOnValueChanged(func(index int) {
+		channel := release.Channels[index]
+		if channel == instance.Channel {
+			return
+		}
+		// The pinned channel is saved once it has a tag.
+		tag := strings.TrimSpace(i.pinnedTagField.Text())
+		if channel == release.ChannelPinned && tag == "" {
+			return
+		}
+		if err := app.Data.UpdateInstance(app.Debug, instance.Name, func(instance *data.Instance) {
+			instance.Channel = channel
+			if channel == release.ChannelPinned {
+				instance.PinnedTag = tag
+			}
+		}); err.Err != nil {
+			app.Debug.SetToast(err)
+			i.synced = ""
+			return
+		}
+		i.refreshReleases(instance.Name)
+	})
+
+	i.pinnedTagField.SetOnEnterPressed(func(text string) {
+		if err := app.Data.UpdateInstance(app.Debug, instance.Name, func(instance *data.Instance) {
+			instance.Channel = release.ChannelPinned
+			instance.PinnedTag = strings.TrimSpace(text)
+		}); err.Err != nil {
+			app.Debug.SetToast(err)
+			return
+		}
+		i.refreshReleases(instance.Name)
+	})
+
+	i.runnerDropdownList.SetOnValueChanged(func(index int) {
+		r := i.runner(instance)
+		if r.Kind == runner.Kinds[index] {
+			return
+		}
+		r.Kind = runner.Kinds[index]
+		i.setRunner(instance.Name, r)
+	})
+	i.binaryField.SetOnEnterPressed(func(text string) {
+		r := i.runner(instance)
+		r.Binary = strings.TrimSpace(text)
+		i.setRunner(instance.Name, r)
+	})
+	i.prefixField.SetOnEnterPressed(func(text string) {
+		r := i.runner(instance)
+		r.Prefix = strings.TrimSpace(text)
+		i.setRunner(instance.Name, r)
+	})
+	i.steamField.SetOnEnterPressed(func(text string) {
+		r := i.runner(instance)
+		r.Steam = strings.TrimSpace(text)
+		i.setRunner(instance.Name, r)
+	})
+	i.envField.SetOnEnterPressed(func(text string) {
+		env, err := runner.ParseEnv(text)
+		if err != nil {
+			app.Debug.SetToast(app.Debug.New(err, debug.DataError, debug.ErrInstanceInvalid))
+			return
+		}
+		r := i.runner(instance)
+		r.Env = env
+		i.setRunner(instance.Name, r)
+	})
+
+	i.installButton.SetOnDown(func() {
+		if app.IsEnqueued(instance.Name) {
+			if err := app.Dequeue(instance.Name); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+			return
+		}
+		if err := app.Enqueue(instance.Name); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+
+	u := float64(basicwidget.UnitSize(context))
+	w, _ := i.Size(context)
+	pt := guigui.Position(i).Add(image.Pt(int(0.5*u), int(0.5*u)))
+
+	i.instanceText.SetText("Instance")
+	i.addInstanceButton.SetText("Add instance")
+	i.channelText.SetText("Channel")
+	i.pinnedTagText.SetText("Pinned tag")
+	i.newInstanceField.SetSize(context, int(8*u), int(u))
+	i.pinnedTagField.SetSize(context, int(8*u), int(u))
+
+	var kinds []string
+	for _, kind := range runner.Kinds {
+		kinds = append(kinds, strings.ToUpper(string(kind[:1]))+string(kind[1:]))
+	}
+	i.runnerDropdownList.SetItemsByStrings(kinds)
+	i.runnerText.SetText("Runner")
+	i.binaryText.SetText("Runner binary")
+	i.prefixText.SetText("Prefix (managed when empty)")
+	i.steamText.SetText("Steam folder (found when empty)")
+	i.envText.SetText("Environment (KEY=value ...)")
+	i.binaryField.SetSize(context, int(8*u), int(u))
+	i.prefixField.SetSize(context, int(8*u), int(u))
+	i.steamField.SetSize(context, int(8*u), int(u))
+	i.envField.SetSize(context, int(8*u), int(u))
+	for _, form := range []*widget.Form{&i.runnerForm, &i.binaryForm, &i.prefixForm, &i.steamForm, &i.envForm} {
+		form.SetWidth(context, w-int(2*u))
+	}
+	i.runnerForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.runnerText, SecondaryWidget: &i.runnerDropdownList},
+	})
+	i.binaryForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.binaryText, SecondaryWidget: &i.binaryField},
+	})
+	i.prefixForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.prefixText, SecondaryWidget: &i.prefixField},
+	})
+	i.steamForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.steamText, SecondaryWidget: &i.steamField},
+	})
+	i.envForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.envText, SecondaryWidget: &i.envField},
+	})
+
+	if instance.Tag != "" {
+		i.statusText.SetText(fmt.Sprintf("Installed: %s", instance.Tag))
+	} else {
+		i.statusText.SetText("Not installed")
+	}
+
+	if app.IsEnqueued(instance.Name) {
+		i.statusText.SetText(downloadStateText(instance.Name))
+	}
+
+	switch update := app.UpdateAvailable(instance.Name); {
+	case app.IsEnqueued(instance.Name):
+		i.installButton.SetText("Remove from queue")
+		guigui.Enable(&i.installButton)
+	case !app.IsInternet():
+		i.installButton.SetText(networkStatusText())
+		guigui.Disable(&i.installButton)
+	case update != nil:
+		i.installButton.SetText(fmt.Sprintf("Update to %s", update.Tag))
+		guigui.Enable(&i.installButton)
+	case app.IsInstalled(instance.Name):
+		i.installButton.SetText("Installed")
+		guigui.Disable(&i.installButton)
+	default:
+		i.installButton.SetText("Install")
+		guigui.Enable(&i.installButton)
+	}
+
+	i.newForm.SetWidth(context, w-int(2*u))
+	i.newForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.newInstanceField, SecondaryWidget: &i.addInstanceButton},
+	})
+	i.channelForm.SetWidth(context, w-int(2*u))
+	i.channelForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.channelText, SecondaryWidget: &i.channelDropdownList},
+	})
+	i.pinnedForm.SetWidth(context, w-int(2*u))
+	i.pinnedForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &i.pinnedTagText, SecondaryWidget: &i.pinnedTagField},
+	})
+
+	i.vLayout.SetHorizontalAlign(widget.HorizontalAlignCenter)
+	i.vLayout.SetBackground(true)
+	i.vLayout.SetLineBreak(false)
+	i.vLayout.SetBorder(true)
+
+	i.vLayout.SetWidth(context, w-int(1*u))
+	guigui.SetPosition(&i.vLayout, pt)
+
+	items := []*widget.LayoutItem{
+		{Widget: &i.instanceText},
+		{Widget: &i.instanceDropdownList},
+		{Widget: &i.newForm},
+		{Widget: &i.channelForm},
+	}
+	// The tag field shows as soon as Pinned is picked, before it is saved.
+	if index := i.channelDropdownList.SelectedItemIndex(); index >= 0 && release.Channels[index] == release.ChannelPinned {
+		items = append(items, &widget.LayoutItem{Widget: &i.pinnedForm})
+	}
+	// Windows builds only need a runner on Linux.
+	if runtime.GOOS == "linux" {
+		items = append(items, &widget.LayoutItem{Widget: &i.runnerForm})
+		if !instance.Runner.IsNative() {
+			items = append(items, &widget.LayoutItem{Widget: &i.binaryForm}, &widget.LayoutItem{Widget: &i.prefixForm})
+		}
+		if instance.Runner != nil && instance.Runner.Kind == runner.Proton {
+			items = append(items, &widget.LayoutItem{Widget: &i.steamForm})
+		}
+		items = append(items, &widget.LayoutItem{Widget: &i.envForm})
+	}
+	items = append(items, i.profiles.items(context, instance, w-int(2*u))...)
+	items = append(items,
+		&widget.LayoutItem{Widget: &i.statusText},
+		&widget.LayoutItem{Widget: &i.installButton},
+	)
+	i.vLayout.SetItems(items)
+	appender.AppendChildWidget(&i.vLayout)
+}
+
+// runner returns a copy of the instance runner to change.
+func (i *Instances) runner(instance *data.Instance) *runner.Runner {
+	if instance.Runner == nil {
+		return &runner.Runner{Kind: runner.Native}
+	}
+	r := *instance.Runner
+	return &r
+}
+
+func (i *Instances) setRunner(name string, r *runner.Runner) {
+	go func() {
+		if err := app.SetRunner(githubContext, name, r); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	}()
+}
+
+func (i *Instances) Update(context *guigui.Context) error {
+	return nil
+}
+
+func (i *Instances) Size(context *guigui.Context) (int, int) {
+	w, h := guigui.Parent(i).Size(context)
+	w -= sidebarWidth(context)
+	return w, h
+}
@@ -24,17 +24,55 @@ package app
 import (
 	"context"
 	"net/http"
+	"p86l/configs"
 	"p86l/internal/cache"
 	"p86l/internal/data"
 	"p86l/internal/debug"
+	"p86l/internal/download"
 	"p86l/internal/file"
+	"p86l/internal/network"
+	"p86l/internal/release"
+	"p86l/internal/secret"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-github/v69/github"
+	"github.com/rs/zerolog/log"
 )
 
 type App struct {
-	isInternet bool
+	monitor        *network.Monitor
+	launcherUpdate atomic.Pointer[release.Release]
+
+	updatesMu sync.Mutex
+	updates   map[string]*release.Release
+	required  map[string]string
+
+	queueMu    sync.Mutex
+	queue      map[string]func()
+	queueOrder []string
+
+	throttle       *download.Throttle
+	downloadsMu    sync.Mutex
+	downloadWake   chan struct{}
+	cancelDownload context.CancelFunc
+	activeDownload atomic.Pointer[DownloadStatus]
+
+	secrets     secret.Store
+	token       atomic.Pointer[string]
+	tokenLocked atomic.Bool
+	rateLimit   atomic.Pointer[github.Rate]
+
+	httpClient     *http.Client
+	httpClientOnce sync.Once
+	missingDeps    atomic.Pointer[MissingDependencies]
+	releaseLock    func()
+	restoreLock    func()
+
+	filesVersion atomic.Uint64
+
+	newsMu sync.Mutex
 
 	Debug *debug.Debug
 	FS    *file.AppFS
@@ -42,29 +80,76 @@ type App struct {
 	Cache *cache.Cache
 }
 
+// HTTPClient is shared by every request of the launcher. It goes through
+// the configured proxy and authenticates GitHub API calls when a token is
+// set.
+func (a *App) HTTPClient() *http.Client {
+	a.httpClientOnce.Do(func() {
+		a.httpClient = &http.Client{
+			Transport: &tokenTransport{
+				base: network.NewTransport(func() string {
+					return a.Data.Network().Proxy
+				}),
+				token: a.currentToken,
+			},
+		}
+	})
+	return a.httpClient
+}
+
+// UpdateChangelog refreshes the changelog for the channel of the default
+// instance.
+func (a *App) UpdateChangelog(githubClient *github.Client, context context.Context) *debug.Error {
+	channel, pinnedTag := release.ChannelStable, ""
+	if instance := a.Data.Instance(configs.DefaultInstance); instance != nil {
+		channel, pinnedTag = instance.Channel, instance.PinnedTag
+	}
+	return a.Cache.InitChangelog(a.Debug, a.ReleaseSource(githubClient), context, channel, pinnedTag)
+}
+
 func (a *App) IsInternet() bool {
-	return a.isInternet
+	return a.NetworkStatus().Status == network.StatusOnline
 }
 
-func (a *App) isInternetReachable() bool {
-	client := http.Client{
-		Timeout: 5 * time.Second,
+func (a *App) NetworkStatus() network.Result {
+	if a.monitor == nil {
+		return network.Result{}
 	}
+	return a.monitor.Result()
+}
+
+func (a *App) probe(ctx context.Context) network.Result {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
-	resp, err := client.Get("https://clients3.google.com/generate_204")
-	if err != nil {
-		return false
+	probeURL := configs.GitHubProbeURL
+	if setting := a.Data.Network().ProbeURL; setting != "" {
+		probeURL = setting
 	}
-	defer resp.Body.Close()
+	prober := &network.Prober{
+		Client:       a.HTTPClient(),
+		GitHubURL:    probeURL,
+		FallbackURLs: configs.FallbackProbeURLs,
+	}
+	return prober.Probe(ctx)
+}
 
-	return resp.StatusCode == 204
+// StartNetworkMonitor starts watching connectivity. onChange is called from
+// the monitor goroutine every time the network status changes.
+func (a *App) StartNetworkMonitor(context context.Context, onChange func(network.Result)) {
+	a.monitor = network.NewMonitor(a.probe, configs.OnlineProbeInterval, configs.OfflineMinBackoff, configs.OfflineMaxBackoff)
+	a.monitor.Subscribe(func(result network.Result) {
+		log.Info().Str("Status", result.Status.String()).Msg("Network status changed")
+	})
+	a.monitor.Subscribe(onChange)
+	go a.monitor.Run(context)
 }
 
-func (a *App) UpdateInternet() {
-	if a.isInternetReachable() {
-		a.isInternet = true
-	} else {
-		a.isInternet = false
+// RecheckNetwork probes connectivity again without waiting for the next
+// interval.
+func (a *App) RecheckNetwork() {
+	if a.monitor != nil {
+		a.monitor.Recheck()
 	}
 }
 
@@ -0,0 +1,300 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/download"
+	"p86l/internal/network"
+	"slices"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/rs/zerolog/log"
+)
+
+// DownloadStatus is the progress of the instance the download queue is
+// working on.
+type DownloadStatus struct {
+	Instance string
+	Stage    string
+	Done     int64
+	Total    int64
+}
+
+// InitDownloads loads the download settings and queue.
+func (a *App) InitDownloads() *debug.Error {
+	a.throttle = download.NewThrottle()
+	a.downloadWake = make(chan struct{}, 1)
+	if err := a.Data.InitDownloads(a.Debug); err.Err != nil {
+		return err
+	}
+	a.applyDownloadSettings()
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (a *App) applyDownloadSettings() {
+	a.throttle.SetLimit(a.Data.Downloads.Limit * 1024)
+	a.throttle.SetPaused(a.Data.Downloads.Paused)
+	schedule, err := a.Data.Downloads.Schedule()
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid download schedule, ignored")
+	}
+	a.throttle.SetSchedule(schedule)
+}
+
+// saveDownloads saves the settings and applies them. The caller holds
+// downloadsMu.
+func (a *App) saveDownloads() *debug.Error {
+	if err := a.Data.SaveDownloads(a.Debug); err.Err != nil {
+		return err
+	}
+	a.applyDownloadSettings()
+	a.wakeDownloads()
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (a *App) wakeDownloads() {
+	select {
+	case a.downloadWake <- struct{}{}:
+	default:
+	}
+}
+
+// SetDownloadLimit caps the download speed in KiB/s. Zero removes the cap.
+func (a *App) SetDownloadLimit(limit int64) *debug.Error {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	previous := a.Data.Downloads.Limit
+	a.Data.Downloads.Limit = limit
+	if err := a.saveDownloads(); err.Err != nil {
+		a.Data.Downloads.Limit = previous
+		return err
+	}
+	log.Info().Int64("Limit", limit).Msg("Download limit changed")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// SetDownloadConnections sets how many parallel connections an asset is
+// fetched over. Zero restores the default.
+func (a *App) SetDownloadConnections(connections int) *debug.Error {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	previous := a.Data.Downloads.Connections
+	a.Data.Downloads.Connections = connections
+	if err := a.saveDownloads(); err.Err != nil {
+		a.Data.Downloads.Connections = previous
+		return err
+	}
+	log.Info().Int("Connections", connections).Msg("Download connections changed")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// SetDownloadSchedule restricts downloads to a daily window given as
+// "15:04" times. Two empty times remove the window.
+func (a *App) SetDownloadSchedule(start, end string) *debug.Error {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	previous := a.Data.Downloads
+	a.Data.Downloads.ScheduleStart, a.Data.Downloads.ScheduleEnd = start, end
+	if err := a.saveDownloads(); err.Err != nil {
+		a.Data.Downloads.ScheduleStart, a.Data.Downloads.ScheduleEnd = previous.ScheduleStart, previous.ScheduleEnd
+		return err
+	}
+	log.Info().Str("Start", start).Str("End", end).Msg("Download schedule changed")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// SetDownloadsPaused pauses or resumes the running download and the queue.
+func (a *App) SetDownloadsPaused(paused bool) *debug.Error {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	previous := a.Data.Downloads.Paused
+	a.Data.Downloads.Paused = paused
+	if err := a.saveDownloads(); err.Err != nil {
+		a.Data.Downloads.Paused = previous
+		return err
+	}
+	log.Info().Bool("Paused", paused).Msg("Downloads paused")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (a *App) DownloadsPaused() bool {
+	return a.throttle != nil && a.throttle.Paused()
+}
+
+func (a *App) DownloadSchedule() download.Schedule {
+	if a.throttle == nil {
+		return download.Schedule{}
+	}
+	return a.throttle.Schedule()
+}
+
+// Enqueue adds an instance to the download queue, to be installed or
+// updated once the network, the schedule and the pause allow it.
+func (a *App) Enqueue(name string) *debug.Error {
+	if a.Data.Instance(name) == nil {
+		return a.Debug.New(errors.New("instance "+name+" not found"), debug.InstallError, debug.ErrInstanceNotFound)
+	}
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	if slices.Contains(a.Data.Downloads.Queue, name) {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	a.Data.Downloads.Queue = append(a.Data.Downloads.Queue, name)
+	log.Info().Str("Instance", name).Msg("Download queued")
+	return a.saveDownloads()
+}
+
+// Dequeue removes an instance from the queue, cancelling its download if
+// it is running.
+func (a *App) Dequeue(name string) *debug.Error {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	if status := a.activeDownload.Load(); status != nil && status.Instance == name && a.cancelDownload != nil {
+		a.cancelDownload()
+	}
+	return a.dequeue(name)
+}
+
+func (a *App) dequeue(name string) *debug.Error {
+	index := slices.Index(a.Data.Downloads.Queue, name)
+	if index < 0 {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	a.Data.Downloads.Queue = slices.Delete(a.Data.Downloads.Queue, index, index+1)
+	log.Info().Str("Instance", name).Msg("Download removed from queue")
+	return a.saveDownloads()
+}
+
+// ResetData restores every setting after the data folder went away.
+func (a *App) ResetData() *debug.Error {
+	a.downloadsMu.Lock()
+	a.Data.Downloads = data.Downloads{}
+	if a.throttle != nil {
+		a.applyDownloadSettings()
+	}
+	a.downloadsMu.Unlock()
+
+	a.newsMu.Lock()
+	a.Data.News = data.News{}
+	a.newsMu.Unlock()
+	return a.Data.HandleDataReset(a.Debug)
+}
+
+// DownloadSettings returns a copy of the download settings.
+func (a *App) DownloadSettings() data.Downloads {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	settings := a.Data.Downloads
+	settings.Queue = slices.Clone(settings.Queue)
+	return settings
+}
+
+func (a *App) DownloadQueue() []string {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	return slices.Clone(a.Data.Downloads.Queue)
+}
+
+func (a *App) IsEnqueued(name string) bool {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	return slices.Contains(a.Data.Downloads.Queue, name)
+}
+
+// ActiveDownload returns the progress of the running download, or nil.
+func (a *App) ActiveDownload() *DownloadStatus {
+	return a.activeDownload.Load()
+}
+
+// nextDownload returns the head of the queue once it may start, with a
+// context cancelled by Dequeue.
+func (a *App) nextDownload(ctx context.Context) (string, context.Context, context.CancelFunc, error) {
+	for {
+		if err := a.throttle.Wait(ctx); err != nil {
+			return "", nil, nil, err
+		}
+		a.downloadsMu.Lock()
+		if len(a.Data.Downloads.Queue) > 0 && a.IsInternet() {
+			name := a.Data.Downloads.Queue[0]
+			downloadCtx, cancel := context.WithCancel(ctx)
+			a.cancelDownload = cancel
+			a.activeDownload.Store(&DownloadStatus{Instance: name})
+			a.downloadsMu.Unlock()
+			return name, downloadCtx, cancel, nil
+		}
+		a.downloadsMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return "", nil, nil, ctx.Err()
+		case <-a.downloadWake:
+		}
+	}
+}
+
+// RunDownloadQueue installs queued instances one after the other until ctx
+// is done. Failed downloads are reported to onError and dropped, unless the
+// network went away, in which case they wait for it.
+func (a *App) RunDownloadQueue(ctx context.Context, githubClient *github.Client, onError func(*debug.Error)) {
+	if a.monitor != nil {
+		a.monitor.Subscribe(func(result network.Result) {
+			if result.Status == network.StatusOnline {
+				a.wakeDownloads()
+			}
+		})
+	}
+
+	for {
+		name, downloadCtx, cancel, err := a.nextDownload(ctx)
+		if err != nil {
+			return
+		}
+		installErr := a.Install(name, githubClient, downloadCtx, func(stage string, done, total int64) {
+			a.activeDownload.Store(&DownloadStatus{Instance: name, Stage: stage, Done: done, Total: total})
+		})
+		cancelled := downloadCtx.Err() != nil
+		cancel()
+
+		a.downloadsMu.Lock()
+		a.activeDownload.Store(nil)
+		a.cancelDownload = nil
+		switch {
+		case ctx.Err() != nil:
+			a.downloadsMu.Unlock()
+			return
+		case installErr.Err == nil:
+			a.dequeue(name)
+		case cancelled:
+			// Removed from the queue by Dequeue.
+		case installErr.Type == debug.NetworkError && !a.IsInternet():
+			log.Info().Str("Instance", name).Msg("Download waits for the network")
+		default:
+			a.dequeue(name)
+			onError(installErr)
+		}
+		a.downloadsMu.Unlock()
+	}
+}
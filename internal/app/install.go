@@ -0,0 +1,313 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"p86l/configs"
+	"p86l/internal/archive"
+	"p86l/internal/asset"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/release"
+	"p86l/internal/selfupdate"
+	"p86l/internal/version"
+	"path/filepath"
+	"runtime"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/rs/zerolog/log"
+)
+
+// InstallProgress reports the current install stage, "download" or
+// "extract", with its progress.
+type InstallProgress func(stage string, done, total int64)
+
+// RequiredSpace is the room needed to download assets and the room needed
+// to unpack them.
+func RequiredSpace(assets ...*release.Asset) (download, extract uint64) {
+	for _, asset := range assets {
+		download += uint64(asset.Size)
+	}
+	return download, uint64(math.Ceil(float64(download) * configs.ExtractionOverhead))
+}
+
+// Preflight checks that the directory of instance name may be installed
+// into, and that there is room to download assets into the downloads
+// directory and to unpack them next to it, which may be on another disk.
+func (a *App) Preflight(name string, rel *release.Release, assets ...*release.Asset) *debug.Error {
+	downloadsDir, err := a.FS.DownloadsDir(a.Debug)
+	if err.Err != nil {
+		return err
+	}
+	instanceDir, err := a.InstanceDir(name)
+	if err.Err != nil {
+		return err
+	}
+	if err := a.checkInstanceDir(name, instanceDir); err.Err != nil {
+		return err
+	}
+	download, extract := RequiredSpace(assets...)
+	required := map[string]uint64{}
+	required[downloadsDir] += download
+	// Extraction stages next to the instance directory.
+	required[filepath.Dir(instanceDir)] += extract
+	if err := a.FS.CheckSpace(a.Debug, required); err.Err != nil {
+		return err
+	}
+	log.Info().Str("Instance", name).Str("Release", rel.Tag).Uint64("Download", download).Uint64("Extract", extract).Msg("Preflight passed")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// ResolveRelease returns the release the instance channel points at.
+func (a *App) ResolveRelease(instance *data.Instance, githubClient *github.Client, context context.Context) (*release.Release, *debug.Error) {
+	resolved, err := release.Resolve(context, a.ReleaseSource(githubClient), instance.Channel, instance.PinnedTag)
+	if err != nil {
+		return nil, a.Debug.New(err, debug.NetworkError, debug.ErrReleaseNetwork)
+	}
+	return resolved, a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// CheckUpdates looks up the channel release of every installed instance.
+func (a *App) CheckUpdates(githubClient *github.Client, context context.Context) *debug.Error {
+	for _, instance := range a.Data.Instances() {
+		if !a.IsInstalled(instance.Name) {
+			continue
+		}
+		resolved, err := a.ResolveRelease(instance, githubClient, context)
+		if err.Err != nil {
+			return err
+		}
+		a.checkCompatible(instance.Name, resolved)
+		a.updatesMu.Lock()
+		if a.updates == nil {
+			a.updates = map[string]*release.Release{}
+		}
+		if resolved.Tag != instance.Tag {
+			log.Info().Str("Instance", instance.Name).Str("Installed", instance.Tag).Str("Available", resolved.Tag).Msg("Update available")
+			a.updates[instance.Name] = resolved
+		} else {
+			delete(a.updates, instance.Name)
+		}
+		a.updatesMu.Unlock()
+	}
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// UpdateAvailable returns the release an installed instance can move to,
+// or nil when it is up to date.
+func (a *App) UpdateAvailable(name string) *release.Release {
+	a.updatesMu.Lock()
+	defer a.updatesMu.Unlock()
+	return a.updates[name]
+}
+
+// checkCompatible refuses rel when it needs a newer launcher, and remembers
+// the version for RequiredLauncherFor.
+func (a *App) checkCompatible(name string, rel *release.Release) *debug.Error {
+	a.updatesMu.Lock()
+	defer a.updatesMu.Unlock()
+	if !launcherTooOld(rel.MinLauncherVersion) {
+		delete(a.required, name)
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	if a.required == nil {
+		a.required = map[string]string{}
+	}
+	a.required[name] = rel.MinLauncherVersion
+	log.Warn().Str("Instance", name).Str("Release", rel.Tag).Str("Required", rel.MinLauncherVersion).Msg("Release needs a newer launcher")
+	err := fmt.Errorf("%s needs launcher %s or newer, this is %s; update the launcher first", rel.Tag, rel.MinLauncherVersion, version.Get().Version)
+	return a.Debug.New(err, debug.InstallError, debug.ErrLauncherOutdated)
+}
+
+// RequiredLauncherFor returns the launcher version instance name waits for,
+// from the launcher manifest or its channel release, or "" when this
+// launcher will do.
+func (a *App) RequiredLauncherFor(name string) string {
+	required := a.RequiredLauncher()
+	a.updatesMu.Lock()
+	defer a.updatesMu.Unlock()
+	if r := a.required[name]; r != "" && (required == "" || selfupdate.Newer(r, required)) {
+		required = r
+	}
+	return required
+}
+
+// SelectAsset picks the build the instance runner starts. A release with
+// only a Windows build points native Linux instances at Wine and Proton.
+func (a *App) SelectAsset(instance *data.Instance, assets []*release.Asset) (*release.Asset, *debug.Error) {
+	goos := instance.Runner.GOOS(runtime.GOOS)
+	selected, err := asset.Select(a.AssetRules(), assets, goos, runtime.GOARCH, instance.AssetPatterns)
+	if err != nil {
+		if goos == "linux" {
+			if _, _err := asset.Select(a.AssetRules(), assets, "windows", runtime.GOARCH, instance.AssetPatterns); _err == nil {
+				err = fmt.Errorf("%w; the release only has a Windows build, set the instance runner to Wine or Proton", err)
+			}
+		}
+		return nil, a.Debug.New(err, debug.InstallError, debug.ErrAssetNotFound)
+	}
+	return selected, a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (a *App) InstanceDir(name string) (string, *debug.Error) {
+	if instance := a.Data.Instance(name); instance != nil && instance.Dir != "" {
+		return instance.Dir, a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	gamesDir, err := a.FS.GamesDir(a.Debug)
+	if err.Err != nil {
+		return "", err
+	}
+	return filepath.Join(gamesDir, name), a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// checkInstanceDir refuses to install over a folder holding files the
+// launcher did not put there. Installs made before manifests existed only
+// live in the games folder; they get one so they can be updated.
+func (a *App) checkInstanceDir(name, dir string) *debug.Error {
+	instance := a.Data.Instance(name)
+	_, dirErr := os.Stat(dir)
+	_, _err := os.Stat(filepath.Join(dir, configs.InstanceManifest))
+	if instance != nil && dirErr == nil && errors.Is(_err, os.ErrNotExist) && instance.Dir == "" && instance.Tag != "" {
+		if err := a.writeManifest(name, instance.Tag); err.Err != nil {
+			return err
+		}
+	}
+	if _err := archive.CheckDest(dir, configs.InstanceManifest); _err != nil {
+		return a.Debug.New(_err, debug.InstallError, debug.ErrInstanceDirInUse)
+	}
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (a *App) IsInstalled(name string) bool {
+	instance := a.Data.Instance(name)
+	if instance == nil || instance.Tag == "" {
+		return false
+	}
+	dir, err := a.InstanceDir(name)
+	if err.Err != nil {
+		return false
+	}
+	_, _err := os.Stat(dir)
+	return _err == nil
+}
+
+// Install downloads the release asset of the instance channel matching the
+// platform and unpacks it into the instance directory. Installing over an
+// existing instance updates it.
+func (a *App) Install(name string, githubClient *github.Client, context context.Context, progress InstallProgress) *debug.Error {
+	instance := a.Data.Instance(name)
+	if instance == nil {
+		return a.Debug.New(errors.New("instance "+name+" not found"), debug.InstallError, debug.ErrInstanceNotFound)
+	}
+	if required := a.RequiredLauncher(); required != "" {
+		return a.Debug.New(fmt.Errorf("launcher %s or newer is required", required), debug.InstallError, debug.ErrLauncherOutdated)
+	}
+
+	source := a.ReleaseSource(githubClient)
+	rel, err := a.ResolveRelease(instance, githubClient, context)
+	if err.Err != nil {
+		return err
+	}
+	if err := a.checkCompatible(name, rel); err.Err != nil {
+		return err
+	}
+	assets, _err := source.ListAssets(context, rel)
+	if _err != nil {
+		return a.Debug.New(_err, debug.NetworkError, debug.ErrReleaseNetwork)
+	}
+	selected, err := a.SelectAsset(instance, assets)
+	if err.Err != nil {
+		return err
+	}
+	if err := a.Preflight(name, rel, selected); err.Err != nil {
+		return err
+	}
+
+	downloadsDir, err := a.FS.DownloadsDir(a.Debug)
+	if err.Err != nil {
+		return err
+	}
+	instanceDir, err := a.InstanceDir(name)
+	if err.Err != nil {
+		return err
+	}
+
+	archivePath := filepath.Join(downloadsDir, selected.Name)
+	log.Info().Str("Instance", name).Str("Asset", selected.Name).Msg("Download")
+	if _err := a.downloadAsset(context, source, rel, assets, selected, archivePath, func(done, total int64) {
+		if progress != nil {
+			progress("download", done, total)
+		}
+	}); _err != nil {
+		return a.Debug.New(_err, debug.NetworkError, debug.ErrDownloadFailed)
+	}
+	defer os.Remove(archivePath)
+
+	log.Info().Str("Instance", name).Str("Dir", instanceDir).Msg("Extract")
+	if _err := archive.Extract(archivePath, instanceDir, archive.Options{
+		StripTopLevel: true,
+		Marker:        configs.InstanceManifest,
+		Progress: func(done, total int64) {
+			if progress != nil {
+				progress("extract", done, total)
+			}
+		},
+	}); _err != nil {
+		return a.Debug.New(_err, debug.InstallError, debug.ErrExtractFailed)
+	}
+	a.FilesChanged()
+	// The former install is kept until the new one could be read back.
+	if err := a.writeManifest(name, rel.Tag); err.Err != nil {
+		if _err := archive.Rollback(instanceDir); _err != nil {
+			log.Error().Err(_err).Str("Dir", instanceDir).Msg("Rollback install")
+		}
+		return err
+	}
+	if _err := archive.Commit(instanceDir); _err != nil {
+		log.Warn().Err(_err).Str("Dir", instanceDir).Msg("Remove previous install")
+	}
+
+	a.updatesMu.Lock()
+	delete(a.updates, name)
+	a.updatesMu.Unlock()
+	if err := a.Data.UpdateInstance(a.Debug, name, func(instance *data.Instance) {
+		instance.Tag = rel.Tag
+	}); err.Err != nil {
+		return err
+	}
+	return a.PreparePrefix(context, name)
+}
+
+// FilesChanged notes that files were added to or removed from the launcher
+// folders, for views of their disk usage.
+func (a *App) FilesChanged() {
+	a.filesVersion.Add(1)
+}
+
+// FilesVersion changes every time FilesChanged is called.
+func (a *App) FilesVersion() uint64 {
+	return a.filesVersion.Load()
+}
@@ -0,0 +1,284 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"p86l/configs"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/deps"
+	"p86l/internal/runner"
+	"p86l/internal/selfupdate"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GameExecutable finds the game binary inside an installed instance. It
+// works offline.
+func (a *App) GameExecutable(name string) (string, *debug.Error) {
+	dir, err := a.InstanceDir(name)
+	if err.Err != nil {
+		return "", err
+	}
+	goos := runtime.GOOS
+	if instance := a.Data.Instance(name); instance != nil {
+		goos = instance.Runner.GOOS(goos)
+	}
+	for _, pattern := range configs.GameExecutables[goos] {
+		matches, _err := filepath.Glob(filepath.Join(dir, pattern))
+		if _err != nil {
+			return "", a.Debug.New(_err, debug.LaunchError, debug.ErrGameNotFound)
+		}
+	match:
+		for _, match := range matches {
+			for _, exclude := range configs.GameExecutableExcludes {
+				if ok, _ := filepath.Match(exclude, filepath.Base(match)); ok {
+					continue match
+				}
+			}
+			if info, _err := os.Stat(match); _err == nil && (goos == "darwin" || !info.IsDir()) {
+				return match, a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+			}
+		}
+	}
+	return "", a.Debug.New(errors.New("no game executable in "+dir), debug.LaunchError, debug.ErrGameNotFound)
+}
+
+// MissingDependencies are the dependencies a launch of Instance stopped on.
+type MissingDependencies struct {
+	Instance string
+	Options  LaunchOptions
+	Missing  []deps.Missing
+}
+
+// CheckDependencies returns what the game needs but this system lacks.
+// Only Linux is checked.
+func (a *App) CheckDependencies() []deps.Missing {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	missing := deps.Default(runtime.GOARCH).Check(configs.LinuxDependencies)
+	if glibc, err := deps.GlibcVersion(); err != nil {
+		log.Warn().Err(err).Msg("Dependency check")
+	} else if selfupdate.Newer(configs.MinGlibc, glibc) {
+		missing = append(missing, deps.Missing{
+			Dependency: configs.Dependency{Name: fmt.Sprintf("glibc %s or newer, found %s", configs.MinGlibc, glibc), Libraries: []string{"libc.so.6"}},
+			Hint:       "upgrade your distribution",
+		})
+	}
+	return missing
+}
+
+// TakeMissingDependencies returns the dependencies the last launch stopped
+// on, once, or nil.
+func (a *App) TakeMissingDependencies() *MissingDependencies {
+	return a.missingDeps.Swap(nil)
+}
+
+// LaunchOptions change a single launch.
+type LaunchOptions struct {
+	// Profile replaces the launch profile selected on the instance.
+	Profile string
+	// SkipChecks launches even when dependencies are missing.
+	SkipChecks bool
+	// NoHooks leaves out the hooks of the launch profile, for launches the
+	// user did not set up, like those from links.
+	NoHooks bool
+}
+
+// Launch starts an installed instance with its selected launch profile,
+// without waiting for it to exit. It refuses to when dependencies are
+// missing.
+func (a *App) Launch(name string) *debug.Error {
+	return a.LaunchWith(name, LaunchOptions{})
+}
+
+// gameCommand builds the command launching instance name with profile,
+// which may be nil. It also returns the variables added to the launcher
+// environment.
+func (a *App) gameCommand(name string, profile *data.LaunchProfile) (*exec.Cmd, []string, *debug.Error) {
+	exe, err := a.GameExecutable(name)
+	if err.Err != nil {
+		return nil, nil, err
+	}
+	dir, err := a.InstanceDir(name)
+	if err.Err != nil {
+		return nil, nil, err
+	}
+	r := a.runner(name)
+	var prefix string
+	if !r.IsNative() {
+		if prefix, err = a.PrefixDir(name); err.Err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var args, env []string
+	if profile != nil {
+		args = profile.Args
+		if profile.Dir != "" {
+			dir = profile.Dir
+		}
+		for key, value := range profile.Env {
+			env = append(env, key+"="+value)
+		}
+		slices.Sort(env)
+	}
+	cmd, _err := r.Command(exe, dir, prefix, args, env)
+	if _err != nil {
+		return nil, nil, a.Debug.New(_err, debug.LaunchError, debug.ErrGameLaunchFailed)
+	}
+	return cmd, append(r.Vars(prefix), env...), a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// launchProfile returns the profile a launch of instance name uses: the
+// one called profile, or the selected one when it is empty.
+func (a *App) launchProfile(name, profile string) (*data.LaunchProfile, *debug.Error) {
+	instance := a.Data.Instance(name)
+	if instance == nil {
+		return nil, a.Debug.New(fmt.Errorf("instance %s not found", name), debug.InstallError, debug.ErrInstanceNotFound)
+	}
+	selected := instance.LaunchProfile(profile)
+	if selected == nil && profile != "" {
+		return nil, a.Debug.New(fmt.Errorf("launch profile %q not found", profile), debug.DataError, debug.ErrInstanceInvalid)
+	}
+	return selected, a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// CommandLine previews the command a launch of instance name with profile
+// runs, preceded by the variables it sets.
+func (a *App) CommandLine(name, profile string) (string, *debug.Error) {
+	selected, err := a.launchProfile(name, profile)
+	if err.Err != nil {
+		return "", err
+	}
+	cmd, vars, err := a.gameCommand(name, selected)
+	if err.Err != nil {
+		return "", err
+	}
+	words := make([]string, 0, len(vars)+len(cmd.Args))
+	for _, v := range vars {
+		key, value, _ := strings.Cut(v, "=")
+		words = append(words, key+"="+runner.JoinArgs([]string{value}))
+	}
+	words = append(words, runner.JoinArgs(cmd.Args))
+	return strings.Join(words, " "), a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// runHook runs a launch profile hook through the shell in dir.
+func runHook(command, dir string, env []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), configs.LaunchHookTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%q: %w: %s", command, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// LaunchWith starts an installed instance without waiting for it to exit.
+// The pre-launch hook of the launch profile runs first and its post-exit
+// hook once the game exits, both with P86L_INSTANCE, P86L_PROFILE and
+// P86L_GAME set; the post-exit hook also gets P86L_EXIT_CODE.
+func (a *App) LaunchWith(name string, opts LaunchOptions) *debug.Error {
+	profile, err := a.launchProfile(name, opts.Profile)
+	if err.Err != nil {
+		return err
+	}
+	r := a.runner(name)
+	if !opts.SkipChecks && r.IsNative() {
+		if missing := a.CheckDependencies(); len(missing) > 0 {
+			names := make([]string, len(missing))
+			for i, m := range missing {
+				names[i] = m.String()
+			}
+			a.missingDeps.Store(&MissingDependencies{Instance: name, Options: opts, Missing: missing})
+			return a.Debug.New(fmt.Errorf("missing %s", strings.Join(names, ", ")), debug.LaunchError, debug.ErrMissingDependency)
+		}
+	}
+	if !r.IsNative() {
+		// The prefix is set up at install time; this covers a runner
+		// chosen afterwards.
+		if err := a.PreparePrefix(context.Background(), name); err.Err != nil {
+			return err
+		}
+	}
+
+	cmd, _, err := a.gameCommand(name, profile)
+	if err.Err != nil {
+		return err
+	}
+	var hookEnv []string
+	if profile != nil {
+		exe, _ := a.GameExecutable(name)
+		hookEnv = []string{"P86L_INSTANCE=" + name, "P86L_PROFILE=" + profile.Name, "P86L_GAME=" + exe}
+		for key, value := range profile.Env {
+			hookEnv = append(hookEnv, key+"="+value)
+		}
+		if profile.PreLaunch != "" && !opts.NoHooks {
+			if _err := runHook(profile.PreLaunch, cmd.Dir, hookEnv); _err != nil {
+				return a.Debug.New(_err, debug.LaunchError, debug.ErrLaunchHookFailed)
+			}
+		}
+	}
+	if _err := cmd.Start(); _err != nil {
+		return a.Debug.New(_err, debug.LaunchError, debug.ErrGameLaunchFailed)
+	}
+	event := log.Info().Str("Instance", name).Strs("Command", cmd.Args).Int("PID", cmd.Process.Pid)
+	if profile != nil {
+		event = event.Str("Profile", profile.Name)
+	}
+	event.Msg("Game launched")
+
+	go func() {
+		_err := cmd.Wait()
+		if _err != nil {
+			log.Warn().Str("Instance", name).Err(_err).Msg("Game exited")
+		} else {
+			log.Info().Str("Instance", name).Msg("Game exited")
+		}
+		if profile == nil || profile.PostExit == "" || opts.NoHooks {
+			return
+		}
+		env := append(hookEnv, fmt.Sprintf("P86L_EXIT_CODE=%d", cmd.ProcessState.ExitCode()))
+		if _err := runHook(profile.PostExit, cmd.Dir, env); _err != nil {
+			log.Warn().Str("Instance", name).Err(_err).Msg("Post-exit hook")
+		}
+	}()
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
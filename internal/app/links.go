@@ -0,0 +1,101 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"p86l/configs"
+	"p86l/internal/cache"
+	"p86l/internal/debug"
+	"p86l/internal/download"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const maxLinksSize = 64 << 10
+
+// ValidLink refuses links that should not reach the browser: anything but
+// an absolute http or https URL.
+func ValidLink(link configs.Link) error {
+	if link.Label == "" {
+		return errors.New("link without a label")
+	}
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("link %q is not a web address", link.URL)
+	}
+	return nil
+}
+
+// Links returns the Home links: the list fetched from configs.LinksURL when
+// there is one, else configs.Links.
+func (a *App) Links() []configs.Link {
+	if links := a.Cache.Links(); configs.LinksURL != "" && links != nil && len(links.Links) > 0 {
+		return links.Links
+	}
+	return configs.Links
+}
+
+// UpdateLinks fetches the link list from configs.LinksURL, when set.
+// Invalid entries are dropped; a list without valid ones is refused.
+func (a *App) UpdateLinks(ctx context.Context) *debug.Error {
+	if configs.LinksURL == "" {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	body, _, _err := download.Open(ctx, a.HTTPClient(), configs.LinksURL)
+	if _err != nil {
+		return a.Debug.New(_err, debug.NetworkError, debug.ErrLinksNetwork)
+	}
+	defer body.Close()
+
+	var remote struct {
+		Links []configs.Link `json:"links"`
+	}
+	if _err := json.NewDecoder(io.LimitReader(body, maxLinksSize)).Decode(&remote); _err != nil {
+		return a.Debug.New(_err, debug.NetworkError, debug.ErrLinksNetwork)
+	}
+	links := &cache.Links{Timestamp: time.Now()}
+	for _, link := range remote.Links {
+		if _err := ValidLink(link); _err != nil {
+			log.Warn().Err(_err).Msg("Remote link dropped")
+			continue
+		}
+		if len(links.Links) == configs.MaxLinks {
+			break
+		}
+		links.Links = append(links.Links, link)
+	}
+	if len(links.Links) == 0 {
+		return a.Debug.New(errors.New("remote link list has no valid links"), debug.NetworkError, debug.ErrLinksNetwork)
+	}
+	log.Info().Int("Links", len(links.Links)).Msg("Links updated")
+	return a.Cache.SaveLinks(a.Debug, links)
+}
@@ -0,0 +1,133 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"p86l/configs"
+	"p86l/internal/debug"
+	"p86l/internal/manifest"
+	"p86l/internal/selfupdate"
+	"p86l/internal/version"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LoadManifest restores the launcher manifest of the last run. Without
+// configs.ManifestURL there is none to load.
+func (a *App) LoadManifest() *debug.Error {
+	if configs.ManifestURL == "" {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	key, err := manifest.ParseKey(configs.ManifestPublicKey)
+	if err != nil {
+		return a.Debug.New(err, debug.AppError, debug.ErrLauncherManifestInvalid)
+	}
+	return a.Cache.LoadManifest(a.Debug, key)
+}
+
+// UpdateManifest fetches the launcher manifest from configs.ManifestURL.
+// A manifest older than the cached one is ignored.
+func (a *App) UpdateManifest(ctx context.Context) *debug.Error {
+	if configs.ManifestURL == "" {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	key, err := manifest.ParseKey(configs.ManifestPublicKey)
+	if err != nil {
+		return a.Debug.New(err, debug.AppError, debug.ErrLauncherManifestInvalid)
+	}
+	m, signed, err := manifest.Fetch(ctx, a.HTTPClient(), configs.ManifestURL, key)
+	if errors.Is(err, manifest.ErrSignature) {
+		return a.Debug.New(err, debug.CacheError, debug.ErrLauncherManifestInvalid)
+	} else if err != nil {
+		return a.Debug.New(err, debug.NetworkError, debug.ErrLauncherManifestNetwork)
+	}
+	if cached := a.Cache.Manifest(); !manifest.Replaces(m, cached) {
+		log.Warn().Int("Fetched", m.Version).Int("Cached", cached.Version).Msg("Older launcher manifest ignored")
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	log.Info().Int("Version", m.Version).Msg("Launcher manifest updated")
+	return a.Cache.SaveManifest(a.Debug, m, signed)
+}
+
+// GameRepo is the GitHub repository of the game releases.
+func (a *App) GameRepo() (owner, name string) {
+	if m := a.Cache.Manifest(); m != nil && m.Repo != nil && m.Repo.Owner != "" && m.Repo.Name != "" {
+		return m.Repo.Owner, m.Repo.Name
+	}
+	return configs.RepoOwner, configs.RepoName
+}
+
+// Feature reports whether a feature flag of configs.Features is on.
+func (a *App) Feature(name string) bool {
+	if m := a.Cache.Manifest(); m != nil {
+		if on, ok := m.Features[name]; ok {
+			return on
+		}
+	}
+	return configs.Features[name]
+}
+
+// Banner is the announcement of the launcher manifest, or nil.
+func (a *App) Banner() *manifest.Banner {
+	if m := a.Cache.Manifest(); m != nil && m.Banner != nil && m.Banner.Text != "" {
+		return m.Banner
+	}
+	return nil
+}
+
+// AssetRules pick the release asset of the game for a platform.
+func (a *App) AssetRules() []configs.AssetRule {
+	if m := a.Cache.Manifest(); m != nil && len(m.AssetRules) > 0 {
+		return m.AssetRules
+	}
+	return configs.AssetRules
+}
+
+// WithdrawnReleases are the tags hidden from every channel.
+func (a *App) WithdrawnReleases() []string {
+	if m := a.Cache.Manifest(); m != nil {
+		return m.Withdrawn
+	}
+	return nil
+}
+
+// RequiredLauncher returns the launcher version the manifest requires when
+// this launcher is older, or "".
+func (a *App) RequiredLauncher() string {
+	m := a.Cache.Manifest()
+	if m == nil || m.MinLauncherVersion == "" {
+		return ""
+	}
+	if launcherTooOld(m.MinLauncherVersion) {
+		return m.MinLauncherVersion
+	}
+	return ""
+}
+
+// launcherTooOld reports whether this launcher is older than required.
+// Untagged builds never are.
+func launcherTooOld(required string) bool {
+	current := version.Get().Version
+	return required != "" && selfupdate.IsVersion(current) && selfupdate.Newer(required, current)
+}
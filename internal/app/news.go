@@ -0,0 +1,166 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"image"
+	"p86l/configs"
+	"p86l/internal/cache"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/news"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// NewsSettings returns a copy of the news settings.
+func (a *App) NewsSettings() data.News {
+	a.newsMu.Lock()
+	defer a.newsMu.Unlock()
+	settings := a.Data.News
+	settings.Read = slices.Clone(settings.Read)
+	return settings
+}
+
+// NewsFeed returns the feed in use, from the settings or configs.
+func (a *App) NewsFeed() string {
+	a.newsMu.Lock()
+	defer a.newsMu.Unlock()
+	if a.Data.News.FeedURL != "" {
+		return a.Data.News.FeedURL
+	}
+	return configs.NewsFeedURL
+}
+
+// News returns the cached items of the feed in use.
+func (a *App) News() []news.Item {
+	if !a.Feature(configs.FeatureNews) {
+		return nil
+	}
+	feed := a.Cache.Feed()
+	if feed == nil || feed.Source != a.NewsFeed() {
+		return nil
+	}
+	return feed.Items
+}
+
+// UpdateNews fetches the feed when the cached copy expired or came from
+// another feed. Remote feeds are left alone while offline.
+func (a *App) UpdateNews(ctx context.Context, force bool) *debug.Error {
+	source := a.NewsFeed()
+	if source == "" || !a.Feature(configs.FeatureNews) || (news.IsRemote(source) && !a.IsInternet()) {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	if feed := a.Cache.Feed(); !force && feed != nil && feed.Source == source && !feed.Expired() {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+
+	items, err := news.Fetch(ctx, a.HTTPClient(), source)
+	if err != nil {
+		return a.Debug.New(err, debug.NetworkError, debug.ErrFeedNetwork)
+	}
+	if len(items) > configs.NewsMaxItems {
+		items = items[:configs.NewsMaxItems]
+	}
+	log.Info().Str("Feed", source).Int("Items", len(items)).Msg("News updated")
+	if err := a.Cache.SaveFeed(a.Debug, &cache.Feed{
+		Source:    source,
+		Items:     items,
+		Timestamp: time.Now(),
+		ExpiresIn: configs.NewsExpiry,
+	}); err.Err != nil {
+		return err
+	}
+
+	// Forget the read state of items that left the feed.
+	a.newsMu.Lock()
+	defer a.newsMu.Unlock()
+	read := slices.DeleteFunc(slices.Clone(a.Data.News.Read), func(id string) bool {
+		return !slices.ContainsFunc(items, func(item news.Item) bool { return item.ID == id })
+	})
+	if len(read) == len(a.Data.News.Read) {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	a.Data.News.Read = read
+	return a.Data.SaveNews(a.Debug)
+}
+
+func (a *App) IsNewsRead(id string) bool {
+	a.newsMu.Lock()
+	defer a.newsMu.Unlock()
+	return slices.Contains(a.Data.News.Read, id)
+}
+
+// SetNewsRead marks the items with the given IDs read or unread.
+func (a *App) SetNewsRead(read bool, ids ...string) *debug.Error {
+	a.newsMu.Lock()
+	defer a.newsMu.Unlock()
+	changed := false
+	for _, id := range ids {
+		index := slices.Index(a.Data.News.Read, id)
+		switch {
+		case read && index < 0:
+			a.Data.News.Read = append(a.Data.News.Read, id)
+		case !read && index >= 0:
+			a.Data.News.Read = slices.Delete(a.Data.News.Read, index, index+1)
+		default:
+			continue
+		}
+		changed = true
+	}
+	if !changed {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	return a.Data.SaveNews(a.Debug)
+}
+
+// SetNewsFeed replaces the feed. An empty feed restores configs.NewsFeedURL.
+// A remote feed set while offline is fetched once the launcher is online.
+func (a *App) SetNewsFeed(ctx context.Context, feed string) *debug.Error {
+	a.newsMu.Lock()
+	previous := a.Data.News.FeedURL
+	a.Data.News.FeedURL = feed
+	if err := a.Data.SaveNews(a.Debug); err.Err != nil {
+		a.Data.News.FeedURL = previous
+		a.newsMu.Unlock()
+		return err
+	}
+	a.newsMu.Unlock()
+	log.Info().Str("Feed", feed).Msg("News feed changed")
+	if news.IsRemote(a.NewsFeed()) && !a.IsInternet() {
+		a.Queue("news", func() {
+			if err := a.UpdateNews(ctx, true); err.Err != nil {
+				a.Debug.SetToast(err)
+			}
+		})
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	return a.UpdateNews(ctx, true)
+}
+
+// NewsThumbnail loads the thumbnail of an item.
+func (a *App) NewsThumbnail(ctx context.Context, item news.Item) (image.Image, error) {
+	return news.Thumbnail(ctx, a.HTTPClient(), item.Thumbnail)
+}
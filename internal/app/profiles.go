@@ -0,0 +1,60 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"slices"
+)
+
+// SaveProfile adds profile to instance name, replacing the one with the
+// same name.
+func (a *App) SaveProfile(name string, profile *data.LaunchProfile) *debug.Error {
+	return a.Data.UpdateInstance(a.Debug, name, func(instance *data.Instance) {
+		index := slices.IndexFunc(instance.Profiles, func(p *data.LaunchProfile) bool { return p.Name == profile.Name })
+		if index >= 0 {
+			instance.Profiles[index] = profile
+		} else {
+			instance.Profiles = append(instance.Profiles, profile)
+		}
+	})
+}
+
+// DeleteProfile removes the profile called profile from instance name,
+// selecting none when it was selected.
+func (a *App) DeleteProfile(name, profile string) *debug.Error {
+	return a.Data.UpdateInstance(a.Debug, name, func(instance *data.Instance) {
+		instance.Profiles = slices.DeleteFunc(instance.Profiles, func(p *data.LaunchProfile) bool { return p.Name == profile })
+		if instance.Profile == profile {
+			instance.Profile = ""
+		}
+	})
+}
+
+// SelectProfile makes launches of instance name use the profile called
+// profile, or none when it is empty.
+func (a *App) SelectProfile(name, profile string) *debug.Error {
+	return a.Data.UpdateInstance(a.Debug, name, func(instance *data.Instance) {
+		instance.Profile = profile
+	})
+}
@@ -0,0 +1,59 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"github.com/rs/zerolog/log"
+)
+
+// Queue holds work that needs the network until the launcher is online
+// again. Operations are keyed so queueing the same work twice runs it once.
+func (a *App) Queue(key string, op func()) {
+	if a.IsInternet() {
+		go op()
+		return
+	}
+
+	a.queueMu.Lock()
+	defer a.queueMu.Unlock()
+	if a.queue == nil {
+		a.queue = map[string]func(){}
+	}
+	if _, ok := a.queue[key]; !ok {
+		a.queueOrder = append(a.queueOrder, key)
+	}
+	a.queue[key] = op
+	log.Info().Str("Operation", key).Msg("Queued until online")
+}
+
+// RunQueued starts every queued operation in the order they were queued.
+func (a *App) RunQueued() {
+	a.queueMu.Lock()
+	order, queue := a.queueOrder, a.queue
+	a.queueOrder, a.queue = nil, nil
+	a.queueMu.Unlock()
+
+	for _, key := range order {
+		log.Info().Str("Operation", key).Msg("Resume queued operation")
+		go queue[key]()
+	}
+}
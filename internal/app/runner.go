@@ -0,0 +1,97 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/runner"
+	"path/filepath"
+	"runtime"
+
+	"github.com/rs/zerolog/log"
+)
+
+// runner returns the runner of instance name, nil when it runs natively.
+func (a *App) runner(name string) *runner.Runner {
+	if instance := a.Data.Instance(name); instance != nil {
+		return instance.Runner
+	}
+	return nil
+}
+
+// PrefixDir is the Wine or Proton prefix of instance name: the one set on
+// its runner, or one managed below the prefixes directory.
+func (a *App) PrefixDir(name string) (string, *debug.Error) {
+	if r := a.runner(name); r != nil && r.Prefix != "" {
+		return r.Prefix, a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	prefixesDir, err := a.FS.PrefixesDir(a.Debug)
+	if err.Err != nil {
+		return "", err
+	}
+	return filepath.Join(prefixesDir, name), a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// PreparePrefix creates the prefix of instance name when its runner needs
+// one that is not set up yet.
+func (a *App) PreparePrefix(ctx context.Context, name string) *debug.Error {
+	r := a.runner(name)
+	if r.IsNative() {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	prefix, err := a.PrefixDir(name)
+	if err.Err != nil {
+		return err
+	}
+	if runner.IsPrepared(prefix) {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	log.Info().Str("Instance", name).Str("Runner", string(r.Kind)).Str("Prefix", prefix).Msg("Prepare prefix")
+	if _err := r.Prepare(ctx, prefix); _err != nil {
+		return a.Debug.New(_err, debug.LaunchError, debug.ErrPrefixFailed)
+	}
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// SetRunner changes the runner of instance name and sets its prefix up
+// when the instance is installed. Moving between native and Windows
+// builds needs the other build, so the instance is marked for install.
+func (a *App) SetRunner(ctx context.Context, name string, r *runner.Runner) *debug.Error {
+	if r.IsNative() && (r == nil || len(r.Env) == 0) {
+		r = nil
+	}
+	if err := a.Data.UpdateInstance(a.Debug, name, func(instance *data.Instance) {
+		if instance.Runner.GOOS(runtime.GOOS) != r.GOOS(runtime.GOOS) && instance.Tag != "" {
+			log.Info().Str("Instance", name).Str("Installed", instance.Tag).Msg("Runner needs another build")
+			instance.Tag = ""
+		}
+		instance.Runner = r
+	}); err.Err != nil {
+		return err
+	}
+	if !a.IsInstalled(name) {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	return a.PreparePrefix(ctx, name)
+}
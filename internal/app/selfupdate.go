@@ -0,0 +1,111 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"p86l/internal/debug"
+	"p86l/internal/download"
+	"p86l/internal/release"
+	"p86l/internal/selfupdate"
+	"p86l/internal/version"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/rs/zerolog/log"
+)
+
+// LauncherUpdate is the newer launcher release found by
+// CheckLauncherUpdate, or nil.
+func (a *App) LauncherUpdate() *release.Release {
+	return a.launcherUpdate.Load()
+}
+
+func (a *App) CheckLauncherUpdate(githubClient *github.Client, context context.Context, current string) *debug.Error {
+	latest, err := selfupdate.Check(context, a.LauncherSource(githubClient), current)
+	if err != nil {
+		return a.Debug.New(err, debug.NetworkError, debug.ErrLauncherUpdateCheck)
+	}
+	if latest != nil {
+		log.Info().Str("Current", current).Str("Latest", latest.Tag).Msg("Launcher update available")
+	}
+	a.launcherUpdate.Store(latest)
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// UpdateLauncher stages the newer launcher and restarts into it.
+func (a *App) UpdateLauncher(githubClient *github.Client, context context.Context, progress download.Progress) *debug.Error {
+	latest := a.LauncherUpdate()
+	if latest == nil {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	if err := selfupdate.Stage(context, a.LauncherSource(githubClient), latest, progress); err != nil {
+		return a.Debug.New(err, debug.AppError, debug.ErrLauncherUpdate)
+	}
+	log.Info().Str("Version", latest.Tag).Msg("Launcher update staged, restarting")
+	if err := a.restart(); err != nil {
+		return a.Debug.New(err, debug.AppError, debug.ErrLauncherUpdate)
+	}
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// UpdateLauncherTo updates the launcher to a release of at least required,
+// checking for one first when none is known yet.
+func (a *App) UpdateLauncherTo(githubClient *github.Client, context context.Context, required string, progress download.Progress) *debug.Error {
+	if a.LauncherUpdate() == nil {
+		if err := a.CheckLauncherUpdate(githubClient, context, version.Get().Version); err.Err != nil {
+			return err
+		}
+	}
+	latest := a.LauncherUpdate()
+	if latest == nil || selfupdate.Newer(required, latest.Tag) {
+		return a.Debug.New(fmt.Errorf("no launcher release %s or newer is available yet", required), debug.AppError, debug.ErrLauncherUpdate)
+	}
+	return a.UpdateLauncher(githubClient, context, progress)
+}
+
+// SetLockHandover makes restarts call release before starting the new
+// launcher, so it can take the single instance lock, and restore when the
+// restart failed and this launcher keeps running.
+func (a *App) SetLockHandover(release, restore func()) {
+	a.releaseLock, a.restoreLock = release, restore
+}
+
+// restart restarts the launcher, taking the lock back when it fails.
+func (a *App) restart() error {
+	err := selfupdate.Restart(a.releaseLock)
+	if err != nil && a.restoreLock != nil {
+		a.restoreLock()
+	}
+	return err
+}
+
+func (a *App) RollbackLauncher() *debug.Error {
+	if err := selfupdate.Rollback(); err != nil {
+		return a.Debug.New(err, debug.AppError, debug.ErrLauncherRollback)
+	}
+	log.Info().Msg("Launcher rolled back, restarting")
+	if err := a.restart(); err != nil {
+		return a.Debug.New(err, debug.AppError, debug.ErrLauncherRollback)
+	}
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
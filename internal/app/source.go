@@ -0,0 +1,143 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"p86l/configs"
+	"p86l/internal/download"
+	"p86l/internal/release"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/rs/zerolog/log"
+)
+
+// ReleaseSource returns where game releases come from: the release index or
+// folder set in the network settings, or the GitHub repository. Releases
+// withdrawn by the launcher manifest are left out.
+func (a *App) ReleaseSource(githubClient *github.Client) release.ReleaseSource {
+	var source release.ReleaseSource
+	switch setting := a.Data.Network().ReleaseSource; {
+	case setting == "":
+		owner, name := a.GameRepo()
+		source = a.githubSource(githubClient, owner, name)
+	case strings.HasPrefix(setting, "http://"), strings.HasPrefix(setting, "https://"):
+		source = &release.IndexSource{Client: a.HTTPClient(), URL: setting}
+	default:
+		source = &release.DirSource{Dir: setting}
+	}
+	if withdrawn := a.WithdrawnReleases(); len(withdrawn) > 0 {
+		return &release.Withdrawn{Source: source, Tags: withdrawn}
+	}
+	return source
+}
+
+// LauncherSource returns the releases of the launcher itself.
+func (a *App) LauncherSource(githubClient *github.Client) release.ReleaseSource {
+	return a.githubSource(githubClient, configs.LauncherRepoOwner, configs.LauncherRepoName)
+}
+
+func (a *App) githubSource(githubClient *github.Client, owner, repo string) *release.GitHubSource {
+	return &release.GitHubSource{
+		Client:     githubClient,
+		HTTPClient: a.HTTPClient(),
+		Owner:      owner,
+		Repo:       repo,
+		// Assets of private releases are only served through the API.
+		API: a.HasToken(),
+	}
+}
+
+// mirrorKey names a download location in the mirror stats.
+func mirrorKey(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return "local"
+}
+
+// downloadCandidates lists where asset can be downloaded from: the release
+// source first, then the mirrors of the asset and the configured mirrors.
+// Mirrors are skipped without a checksum to verify what they serve.
+func (a *App) downloadCandidates(source release.ReleaseSource, rel *release.Release, asset *release.Asset, verified bool) []download.Candidate {
+	primary := download.Candidate{
+		Key: mirrorKey(asset.URL),
+		Open: func(ctx context.Context) (io.ReadCloser, int64, error) {
+			return source.OpenAsset(ctx, asset)
+		},
+	}
+	primary.URL, primary.Client = release.HTTPAsset(source, asset)
+	candidates := []download.Candidate{primary}
+	if !verified {
+		return candidates
+	}
+
+	mirrors := slices.Clone(asset.Mirrors)
+	for _, base := range slices.Concat(configs.AssetMirrors, a.Data.Network().Mirrors) {
+		mirrors = append(mirrors, strings.TrimSuffix(base, "/")+"/"+url.PathEscape(rel.Tag)+"/"+url.PathEscape(asset.Name))
+	}
+	for _, mirror := range mirrors {
+		candidates = append(candidates, download.Candidate{
+			Key:    mirrorKey(mirror),
+			URL:    mirror,
+			Client: a.HTTPClient(),
+		})
+	}
+	return candidates
+}
+
+// downloadAsset saves asset to dest from the fastest working mirror and
+// checks it against the published checksum, if there is one.
+func (a *App) downloadAsset(ctx context.Context, source release.ReleaseSource, rel *release.Release, assets []*release.Asset, asset *release.Asset, dest string, progress download.Progress) error {
+	sum, err := release.Checksum(ctx, source, assets, asset)
+	if err != nil && !errors.Is(err, release.ErrNoChecksum) {
+		return err
+	}
+	if sum == "" {
+		log.Warn().Str("Asset", asset.Name).Msg("No checksum, mirrors disabled")
+	}
+	settings := a.DownloadSettings()
+	connections := settings.DownloadConnections()
+	if !a.Feature(configs.FeatureRangedDownloads) {
+		connections = 1
+	}
+
+	err = download.Failover(ctx, a.downloadCandidates(source, rel, asset, sum != ""), dest, download.FailoverOptions{
+		SHA256:      sum,
+		MinSpeed:    configs.MirrorMinSpeed,
+		SlowWindow:  configs.MirrorSlowWindow,
+		Stats:       a.Cache.Mirrors,
+		Throttle:    a.throttle,
+		Connections: connections,
+		MinPartSize: configs.MinDownloadPart,
+		Progress:    progress,
+	})
+	if err := a.Cache.SaveMirrors(a.Debug); err.Err != nil {
+		log.Warn().Err(err.Err).Msg("Save mirror stats")
+	}
+	return err
+}
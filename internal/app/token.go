@@ -0,0 +1,188 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"p86l/configs"
+	"p86l/internal/debug"
+	"p86l/internal/secret"
+	"p86l/internal/version"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v69/github"
+	"github.com/rs/zerolog/log"
+)
+
+// tokenTransport adds the GitHub token to requests for
+// configs.GitHubTokenHosts. Requests that already carry credentials are
+// left alone.
+type tokenTransport struct {
+	base  http.RoundTripper
+	token func() string
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.token()
+	if token != "" && req.Header.Get("Authorization") == "" && slices.Contains(configs.GitHubTokenHosts, req.URL.Hostname()) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return t.base.RoundTrip(req)
+}
+
+func (a *App) currentToken() string {
+	if token := a.token.Load(); token != nil {
+		return *token
+	}
+	return ""
+}
+
+func (a *App) HasToken() bool {
+	return a.currentToken() != ""
+}
+
+// TokenStore describes where the token is kept.
+func (a *App) TokenStore() string {
+	if a.secrets == nil {
+		return ""
+	}
+	return a.secrets.Name()
+}
+
+// RateLimit is the core GitHub API rate limit seen by the last
+// CheckRateLimit, or nil.
+func (a *App) RateLimit() *github.Rate {
+	return a.rateLimit.Load()
+}
+
+// InitToken opens the secret store and loads the GitHub token, if one was
+// saved. It never asks to unlock the keyring: a locked one is left for
+// UnlockToken, and the launcher runs without the token until then.
+func (a *App) InitToken() *debug.Error {
+	dataDir, err := a.FS.DataDir(a.Debug)
+	if err.Err != nil {
+		return err
+	}
+	a.secrets = secret.New(dataDir)
+	return a.loadToken()
+}
+
+// UnlockToken lets the secret store ask the user to unlock it and loads
+// the token if the store was locked. Only the window calls it, once shown.
+func (a *App) UnlockToken() *debug.Error {
+	if a.secrets == nil {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	secret.AllowPrompt(a.secrets)
+	if !a.tokenLocked.Swap(false) {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	return a.loadToken()
+}
+
+func (a *App) loadToken() *debug.Error {
+	token, _err := a.secrets.Get(configs.GitHubTokenKey)
+	if errors.Is(_err, secret.ErrNotFound) {
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	if errors.Is(_err, secret.ErrLocked) {
+		a.tokenLocked.Store(true)
+		log.Info().Str("Store", a.secrets.Name()).Msg("Keyring locked, GitHub token not loaded")
+		return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	if _err != nil {
+		return a.Debug.New(_err, debug.AuthError, debug.ErrTokenLoad)
+	}
+	a.token.Store(&token)
+	log.Info().Str("Store", a.secrets.Name()).Msg("GitHub token loaded")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (a *App) githubRateLimit(context context.Context, token string) (*github.Rate, error) {
+	client := github.NewClient(a.HTTPClient())
+	client.UserAgent = version.UserAgent()
+	if token != "" {
+		client = client.WithAuthToken(token)
+	}
+	limits, _, err := client.RateLimit.Get(context)
+	if err != nil {
+		return nil, err
+	}
+	if limits.GetCore() == nil {
+		return nil, errors.New("rate limit response without core limit")
+	}
+	return limits.GetCore(), nil
+}
+
+// SetToken validates token against GitHub and saves it. The token itself is
+// never logged.
+func (a *App) SetToken(context context.Context, token string) *debug.Error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return a.ClearToken()
+	}
+	if a.secrets == nil {
+		return a.Debug.New(errors.New("secret store not initialised"), debug.AuthError, debug.ErrTokenSave)
+	}
+
+	rate, err := a.githubRateLimit(context, token)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusUnauthorized {
+			return a.Debug.New(errors.New("GitHub rejected the token"), debug.AuthError, debug.ErrTokenInvalid)
+		}
+		return a.Debug.New(err, debug.NetworkError, debug.ErrRateLimitCheck)
+	}
+	if err := a.secrets.Set(configs.GitHubTokenKey, token); err != nil {
+		return a.Debug.New(err, debug.AuthError, debug.ErrTokenSave)
+	}
+	a.token.Store(&token)
+	a.rateLimit.Store(rate)
+	log.Info().Str("Store", a.secrets.Name()).Int("Limit", rate.Limit).Msg("GitHub token saved")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (a *App) ClearToken() *debug.Error {
+	if a.secrets != nil {
+		if err := a.secrets.Delete(configs.GitHubTokenKey); err != nil {
+			return a.Debug.New(err, debug.AuthError, debug.ErrTokenSave)
+		}
+	}
+	a.token.Store(nil)
+	a.rateLimit.Store(nil)
+	log.Info().Msg("GitHub token removed")
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// CheckRateLimit refreshes RateLimit with the current token, if any.
+func (a *App) CheckRateLimit(context context.Context) *debug.Error {
+	rate, err := a.githubRateLimit(context, "")
+	if err != nil {
+		return a.Debug.New(err, debug.NetworkError, debug.ErrRateLimitCheck)
+	}
+	a.rateLimit.Store(rate)
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
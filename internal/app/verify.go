@@ -0,0 +1,146 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"p86l/configs"
+	"p86l/internal/debug"
+	"path/filepath"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Manifest records the files of an installed instance.
+type Manifest struct {
+	Tag   string
+	Files map[string]ManifestFile
+}
+
+type ManifestFile struct {
+	Size   int64
+	SHA256 string
+}
+
+// Problem is a file that no longer matches the manifest.
+type Problem struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func hashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	n, err := io.Copy(hash, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), n, nil
+}
+
+// writeManifest hashes every file of a freshly installed instance.
+func (a *App) writeManifest(name, tag string) *debug.Error {
+	dir, err := a.InstanceDir(name)
+	if err.Err != nil {
+		return err
+	}
+	manifest := Manifest{Tag: tag, Files: map[string]ManifestFile{}}
+	_err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == configs.InstanceManifest {
+			return err
+		}
+		sum, size, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		manifest.Files[filepath.ToSlash(rel)] = ManifestFile{Size: size, SHA256: sum}
+		return nil
+	})
+	if _err != nil {
+		return a.Debug.New(_err, debug.InstallError, debug.ErrManifestSave)
+	}
+	manifestBytes, _err := json.Marshal(manifest)
+	if _err != nil {
+		return a.Debug.New(_err, debug.InstallError, debug.ErrManifestSave)
+	}
+	if _err := os.WriteFile(filepath.Join(dir, configs.InstanceManifest), manifestBytes, 0644); _err != nil {
+		return a.Debug.New(_err, debug.InstallError, debug.ErrManifestSave)
+	}
+	return a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// Verify checks the files of an installed instance against the manifest
+// written when it was installed and returns the ones that are missing or
+// changed. Extra files, such as saves, are ignored.
+func (a *App) Verify(name string) ([]Problem, *debug.Error) {
+	if !a.IsInstalled(name) {
+		return nil, a.Debug.New(fmt.Errorf("instance %s is not installed", name), debug.InstallError, debug.ErrInstanceNotFound)
+	}
+	dir, err := a.InstanceDir(name)
+	if err.Err != nil {
+		return nil, err
+	}
+	manifestJSON, _err := os.ReadFile(filepath.Join(dir, configs.InstanceManifest))
+	if errors.Is(_err, fs.ErrNotExist) {
+		return nil, a.Debug.New(fmt.Errorf("instance %s has no manifest, reinstall it to verify", name), debug.InstallError, debug.ErrVerifyFailed)
+	}
+	if _err != nil {
+		return nil, a.Debug.New(_err, debug.InstallError, debug.ErrVerifyFailed)
+	}
+	var manifest Manifest
+	if _err := json.Unmarshal(manifestJSON, &manifest); _err != nil {
+		return nil, a.Debug.New(_err, debug.InstallError, debug.ErrVerifyFailed)
+	}
+
+	var problems []Problem
+	for _, path := range slices.Sorted(maps.Keys(manifest.Files)) {
+		want := manifest.Files[path]
+		sum, size, _err := hashFile(filepath.Join(dir, filepath.FromSlash(path)))
+		switch {
+		case errors.Is(_err, fs.ErrNotExist):
+			problems = append(problems, Problem{Path: path, Reason: "missing"})
+		case _err != nil:
+			problems = append(problems, Problem{Path: path, Reason: _err.Error()})
+		case size != want.Size || sum != want.SHA256:
+			problems = append(problems, Problem{Path: path, Reason: "modified"})
+		}
+	}
+	log.Info().Str("Instance", name).Int("Files", len(manifest.Files)).Int("Problems", len(problems)).Msg("Verified")
+	return problems, a.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
@@ -0,0 +1,345 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package archive unpacks downloaded releases into place.
+//
+// Entries are written to a staging directory next to the destination, which
+// is only renamed over the destination once everything has been extracted,
+// so an interrupted extraction never leaves a half written game behind.
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Format int
+
+const (
+	FormatUnknown Format = iota
+	FormatZip
+	FormatTarGz
+	FormatAppImage
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatZip:
+		return "zip"
+	case FormatTarGz:
+		return "tar.gz"
+	case FormatAppImage:
+		return "AppImage"
+	}
+	return "unknown"
+}
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported archive format")
+	ErrUnsafePath        = errors.New("archive entry escapes the destination")
+	ErrNotManaged        = errors.New("destination holds files the launcher did not install")
+)
+
+// Progress is called while extracting with the bytes processed so far and
+// the expected total.
+type Progress func(done, total int64)
+
+type Options struct {
+	// StripTopLevel removes a single directory wrapping every entry.
+	StripTopLevel bool
+	// Marker names the file marking the destination as installed by the
+	// launcher. Only a destination holding it, or an empty one, is
+	// replaced.
+	Marker   string
+	Progress Progress
+}
+
+func DetectFormat(name string) Format {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return FormatZip
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return FormatTarGz
+	case strings.HasSuffix(name, ".appimage"):
+		return FormatAppImage
+	}
+	return FormatUnknown
+}
+
+// Extract unpacks src into dest, replacing dest atomically. The previous
+// dest is kept until Commit or Rollback is called.
+func Extract(src, dest string, opts Options) error {
+	format := DetectFormat(src)
+	if format == FormatUnknown {
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Base(src))
+	}
+
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	if err := CheckDest(dest, opts.Marker); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".staging-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	if err := os.Chmod(staging, 0755); err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatZip:
+		err = extractZip(src, staging, opts.Progress)
+	case FormatTarGz:
+		err = extractTarGz(src, staging, opts.Progress)
+	case FormatAppImage:
+		err = copyAppImage(src, staging, opts.Progress)
+	}
+	if err != nil {
+		return err
+	}
+
+	root := staging
+	if opts.StripTopLevel {
+		if root, err = singleTopLevel(staging); err != nil {
+			return err
+		}
+	}
+	return replace(root, dest, opts.Marker)
+}
+
+// singleTopLevel returns the only directory inside dir, or dir itself when
+// it holds anything else.
+func singleTopLevel(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+	return dir, nil
+}
+
+// CheckDest fails with ErrNotManaged unless dest is missing, an empty
+// directory or a directory holding marker, so that a folder picked by the
+// user is never replaced along with their files.
+func CheckDest(dest, marker string) error {
+	info, err := os.Lstat(dest)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", ErrNotManaged, dest)
+	}
+	if marker != "" {
+		if _, err := os.Lstat(filepath.Join(dest, marker)); err == nil {
+			return nil
+		}
+	}
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%w: %s", ErrNotManaged, dest)
+	}
+	return nil
+}
+
+// previous is where replace keeps the former dest until Commit or
+// Rollback.
+func previous(dest string) string {
+	return filepath.Join(filepath.Dir(dest), "."+filepath.Base(dest)+".previous")
+}
+
+// replace moves src to dest, keeping the former dest aside.
+func replace(src, dest, marker string) error {
+	// Checked again as dest may have changed during the extraction.
+	if err := CheckDest(dest, marker); err != nil {
+		return err
+	}
+	old := previous(dest)
+	if err := os.RemoveAll(old); err != nil {
+		return err
+	}
+	hadDest := false
+	if _, err := os.Lstat(dest); err == nil {
+		if err := os.Rename(dest, old); err != nil {
+			return err
+		}
+		hadDest = true
+	}
+	if err := os.Rename(src, dest); err != nil {
+		if hadDest {
+			os.Rename(old, dest)
+		}
+		return err
+	}
+	return nil
+}
+
+// Commit removes the former dest kept by Extract, once the new one has
+// been checked.
+func Commit(dest string) error {
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(previous(dest))
+}
+
+// Rollback puts back the former dest kept by Extract, or removes dest when
+// there was none.
+func Rollback(dest string) error {
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	old := previous(dest)
+	if _, err := os.Lstat(old); errors.Is(err, fs.ErrNotExist) {
+		return os.RemoveAll(dest)
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	return os.Rename(old, dest)
+}
+
+// safeJoin resolves name below root and rejects entries that would end up
+// outside of it. The text of name is not enough: links extracted earlier
+// could lead anywhere, so entries going through one are rejected as well.
+func safeJoin(root, name string) (string, error) {
+	name = filepath.FromSlash(name)
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+	path := filepath.Join(root, name)
+	if !within(root, path) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafePath, name)
+	}
+	rel, _ := filepath.Rel(root, path)
+	if err := noLinks(root, rel); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// noLinks walks the components of rel from dir, ".." included, and fails
+// on the first one that is a symbolic link on disk.
+func noLinks(dir, rel string) error {
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			dir = filepath.Dir(dir)
+			continue
+		}
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%w: %s goes through a link", ErrUnsafePath, rel)
+		}
+	}
+	return nil
+}
+
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// The umask may have dropped the executable bit.
+	return os.Chmod(path, mode.Perm())
+}
+
+// writeSymlink creates a link at path, refusing targets that point outside
+// of root. Targets going through another link are refused too, as they
+// resolve elsewhere than their text says.
+func writeSymlink(root, path, target string) error {
+	target = filepath.FromSlash(target)
+	if filepath.IsAbs(target) || filepath.VolumeName(target) != "" || !within(root, filepath.Join(filepath.Dir(path), target)) {
+		return fmt.Errorf("%w: %s -> %s", ErrUnsafePath, path, target)
+	}
+	if err := noLinks(filepath.Dir(path), target); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.Symlink(target, path)
+}
+
+type progressReader struct {
+	r        io.Reader
+	done     int64
+	total    int64
+	progress Progress
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.done += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.done, p.total)
+	}
+	return n, err
+}
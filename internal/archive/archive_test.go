@@ -0,0 +1,311 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// entry is an archive member: a directory when its name ends in a slash, a
+// symbolic link when link is set, a regular file otherwise.
+type entry struct {
+	name, link, body string
+}
+
+func writeTarGz(t *testing.T, path string, entries []entry) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Typeflag: tar.TypeReg, Size: int64(len(e.body))}
+		switch {
+		case e.link != "":
+			hdr = &tar.Header{Name: e.name, Mode: 0777, Typeflag: tar.TypeSymlink, Linkname: e.link}
+		case e.name[len(e.name)-1] == '/':
+			hdr = &tar.Header{Name: e.name, Mode: 0755, Typeflag: tar.TypeDir}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, path string, entries []entry) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		body := e.body
+		switch {
+		case e.link != "":
+			hdr.SetMode(fs.ModeSymlink | 0777)
+			body = e.link
+		case e.name[len(e.name)-1] == '/':
+			hdr.SetMode(fs.ModeDir | 0755)
+		default:
+			hdr.SetMode(0644)
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []entry
+		unsafe  bool
+		want    map[string]string
+	}{
+		{
+			name:    "regular",
+			entries: []entry{{name: "game/"}, {name: "game/data/a.txt", body: "a"}, {name: "game/lib.so", link: "data/a.txt"}},
+			want:    map[string]string{"game/data/a.txt": "a", "game/lib.so": "a"},
+		},
+		{name: "parent", entries: []entry{{name: "../x", body: "x"}}, unsafe: true},
+		{name: "nested parent", entries: []entry{{name: "a/../../x", body: "x"}}, unsafe: true},
+		{name: "absolute", entries: []entry{{name: "/tmp/x", body: "x"}}, unsafe: true},
+		{name: "link outside", entries: []entry{{name: "l", link: "../.."}}, unsafe: true},
+		{name: "absolute link", entries: []entry{{name: "l", link: "/etc"}}, unsafe: true},
+		{
+			name:    "write through link",
+			entries: []entry{{name: "d/"}, {name: "l", link: "d"}, {name: "l/x", body: "x"}},
+			unsafe:  true,
+		},
+		{
+			name:    "link chain",
+			entries: []entry{{name: "d/"}, {name: "d/l", link: ".."}, {name: "d/l/m", link: ".."}, {name: "d/l/m/x", body: "x"}},
+			unsafe:  true,
+		},
+		{
+			name:    "link target through link",
+			entries: []entry{{name: "d/"}, {name: "d/l", link: ".."}, {name: "d/e", link: "l/../.."}},
+			unsafe:  true,
+		},
+		{
+			name:    "overwrite link",
+			entries: []entry{{name: "a", body: "a"}, {name: "l", link: "a"}, {name: "l", body: "x"}},
+			unsafe:  true,
+		},
+	}
+	for _, format := range []string{"tar.gz", "zip"} {
+		for _, tt := range tests {
+			if format == "zip" && tt.name == "absolute" {
+				// Zip writers refuse absolute names.
+				continue
+			}
+			t.Run(format+"/"+tt.name, func(t *testing.T) {
+				// Nest the destination so escapes land in a directory the
+				// test owns.
+				base := t.TempDir()
+				dest := filepath.Join(base, "a", "b", "game")
+				src := filepath.Join(base, "release."+format)
+				if format == "zip" {
+					writeZip(t, src, tt.entries)
+				} else {
+					writeTarGz(t, src, tt.entries)
+				}
+
+				err := Extract(src, dest, Options{})
+				if tt.unsafe {
+					if !errors.Is(err, ErrUnsafePath) {
+						t.Fatalf("Extract = %v, want %v", err, ErrUnsafePath)
+					}
+					if _, err := os.Stat(dest); !errors.Is(err, fs.ErrNotExist) {
+						t.Errorf("destination exists after a refused archive")
+					}
+					for _, leak := range []string{"x", "a/x", "a/b/x"} {
+						if _, err := os.Lstat(filepath.Join(base, leak)); err == nil {
+							t.Errorf("%s written outside the destination", leak)
+						}
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				for name, body := range tt.want {
+					b, err := os.ReadFile(filepath.Join(dest, name))
+					if err != nil {
+						t.Fatal(err)
+					}
+					if string(b) != body {
+						t.Errorf("%s = %q, want %q", name, b, body)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestExtractStripTopLevel(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "release.tar.gz")
+	writeTarGz(t, src, []entry{{name: "p86-1.0/"}, {name: "p86-1.0/game", body: "bin"}})
+	dest := filepath.Join(base, "game")
+	if err := Extract(src, dest, Options{StripTopLevel: true}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(filepath.Join(dest, "game")); err != nil || string(b) != "bin" {
+		t.Errorf("game = %q, %v", b, err)
+	}
+}
+
+func TestExtractReplace(t *testing.T) {
+	const marker = ".manifest"
+	tests := []struct {
+		name     string
+		existing map[string]string
+		wantErr  error
+	}{
+		{name: "missing"},
+		{name: "empty", existing: map[string]string{}},
+		{name: "user files", existing: map[string]string{"photo.png": "photo", "saves/slot1": "save"}, wantErr: ErrNotManaged},
+		{name: "previous install", existing: map[string]string{marker: "{}", "game": "old"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			src := filepath.Join(base, "release.tar.gz")
+			writeTarGz(t, src, []entry{{name: "game", body: "new"}})
+			dest := filepath.Join(base, "Games")
+			if tt.existing != nil {
+				if err := os.Mkdir(dest, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for name, body := range tt.existing {
+				path := filepath.Join(dest, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := Extract(src, dest, Options{Marker: marker})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Extract = %v, want %v", err, tt.wantErr)
+				}
+				// Nothing of the user was touched or left behind.
+				for name, body := range tt.existing {
+					if b, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(name))); err != nil || string(b) != body {
+						t.Errorf("%s = %q, %v, want %q", name, b, err, body)
+					}
+				}
+				if entries, _ := os.ReadDir(base); len(entries) != 2 {
+					t.Errorf("%d entries next to the destination, want 2", len(entries))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b, err := os.ReadFile(filepath.Join(dest, "game")); err != nil || string(b) != "new" {
+				t.Errorf("game = %q, %v, want new", b, err)
+			}
+
+			// Rolling back restores the former destination as it was.
+			if err := Rollback(dest); err != nil {
+				t.Fatal(err)
+			}
+			if tt.existing == nil {
+				if _, err := os.Lstat(dest); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("destination exists after rolling back a first install")
+				}
+			}
+			for name, body := range tt.existing {
+				if b, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(name))); err != nil || string(b) != body {
+					t.Errorf("%s = %q, %v after rollback, want %q", name, b, err, body)
+				}
+			}
+
+			// Committing drops it.
+			if err := Extract(src, dest, Options{Marker: marker}); err != nil {
+				t.Fatal(err)
+			}
+			if err := Commit(dest); err != nil {
+				t.Fatal(err)
+			}
+			entries, err := os.ReadDir(base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 2 {
+				t.Errorf("%d entries next to the destination after commit, want 2", len(entries))
+			}
+		})
+	}
+}
+
+func TestExtractOverFile(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "release.tar.gz")
+	writeTarGz(t, src, []entry{{name: "game", body: "new"}})
+	dest := filepath.Join(base, "notes.txt")
+	if err := os.WriteFile(dest, []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Extract(src, dest, Options{Marker: ".manifest"}); !errors.Is(err, ErrNotManaged) {
+		t.Fatalf("Extract = %v, want %v", err, ErrNotManaged)
+	}
+	if b, _ := os.ReadFile(dest); string(b) != "notes" {
+		t.Errorf("file replaced: %q", b)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"fmt"
+	"p86l/configs"
+	"p86l/internal/release"
+	"path"
+	"strings"
+)
+
+type NoMatchError struct {
+	OS        string
+	Arch      string
+	Available []string
+}
+
+func (e *NoMatchError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("no asset for %s/%s: the release has no assets", e.OS, e.Arch)
+	}
+	return fmt.Sprintf("no asset for %s/%s, available: %s", e.OS, e.Arch, strings.Join(e.Available, ", "))
+}
+
+// rulesFor returns the rules of a platform. An override, such as the one
+// set on an instance, replaces the rules.
+func rulesFor(rules []configs.AssetRule, goos, goarch string, override []string) []configs.AssetRule {
+	if len(override) > 0 {
+		return []configs.AssetRule{{OS: goos, Arch: goarch, Patterns: override}}
+	}
+	var matching []configs.AssetRule
+	for _, rule := range rules {
+		if rule.OS == goos && (rule.Arch == "" || rule.Arch == goarch) {
+			matching = append(matching, rule)
+		}
+	}
+	return matching
+}
+
+func match(pattern, name string) (bool, error) {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	if err != nil {
+		return false, fmt.Errorf("bad asset pattern %q: %w", pattern, err)
+	}
+	return ok, nil
+}
+
+func excluded(rule configs.AssetRule, name string) (bool, error) {
+	for _, exclude := range rule.Excludes {
+		if ok, err := match(exclude, name); ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// Select returns the first asset matching the platform patterns and none of
+// the excludes of their rule. Patterns are tried in order, so earlier ones
+// win over later ones.
+func Select(rules []configs.AssetRule, assets []*release.Asset, goos, goarch string, override []string) (*release.Asset, error) {
+	for _, rule := range rulesFor(rules, goos, goarch, override) {
+		for _, pattern := range rule.Patterns {
+			for _, asset := range assets {
+				ok, err := match(pattern, asset.Name)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+				excluded, err := excluded(rule, asset.Name)
+				if err != nil {
+					return nil, err
+				}
+				if !excluded {
+					return asset, nil
+				}
+			}
+		}
+	}
+
+	err := &NoMatchError{OS: goos, Arch: goarch}
+	for _, asset := range assets {
+		err.Available = append(err.Available, asset.Name)
+	}
+	return nil, err
+}
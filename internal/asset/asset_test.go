@@ -0,0 +1,114 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"errors"
+	"p86l/configs"
+	"p86l/internal/release"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	assets := func(names ...string) []*release.Asset {
+		var list []*release.Asset
+		for _, name := range names {
+			list = append(list, &release.Asset{Name: name})
+		}
+		return list
+	}
+	tests := []struct {
+		name     string
+		assets   []*release.Asset
+		goos     string
+		goarch   string
+		override []string
+		want     string
+	}{
+		{
+			name:   "windows amd64 skips darwin",
+			assets: assets("p86-darwin.zip", "p86-windows.zip"),
+			goos:   "windows", goarch: "amd64",
+			want: "p86-windows.zip",
+		},
+		{
+			name:   "windows amd64 skips arm64",
+			assets: assets("p86-windows-arm64.zip", "p86-windows.zip"),
+			goos:   "windows", goarch: "amd64",
+			want: "p86-windows.zip",
+		},
+		{
+			name:   "windows amd64 only arm64",
+			assets: assets("p86-windows-arm64.zip", "p86-darwin.zip"),
+			goos:   "windows", goarch: "amd64",
+		},
+		{
+			name:   "windows arm64",
+			assets: assets("p86-windows-x64.zip", "p86-windows-arm64.zip"),
+			goos:   "windows", goarch: "arm64",
+			want: "p86-windows-arm64.zip",
+		},
+		{
+			name:   "linux amd64 skips aarch64",
+			assets: assets("P86-Linux-aarch64.tar.gz", "P86-Linux.tar.gz"),
+			goos:   "linux", goarch: "amd64",
+			want: "P86-Linux.tar.gz",
+		},
+		{
+			name:   "linux amd64 prefers the AppImage",
+			assets: assets("p86-linux.tar.gz", "p86-linux-x86_64.AppImage"),
+			goos:   "linux", goarch: "amd64",
+			want: "p86-linux-x86_64.AppImage",
+		},
+		{
+			name:   "darwin",
+			assets: assets("p86-windows.zip", "p86-macOS.zip"),
+			goos:   "darwin", goarch: "arm64",
+			want: "p86-macOS.zip",
+		},
+		{
+			name:   "override",
+			assets: assets("p86-windows.zip", "custom-build.7z"),
+			goos:   "windows", goarch: "amd64",
+			override: []string{"custom-*"},
+			want:     "custom-build.7z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Select(configs.AssetRules, tt.assets, tt.goos, tt.goarch, tt.override)
+			if tt.want == "" {
+				var noMatch *NoMatchError
+				if !errors.As(err, &noMatch) {
+					t.Fatalf("Select = %v, %v, want no match", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("Select = %s, want %s", got.Name, tt.want)
+			}
+		})
+	}
+}
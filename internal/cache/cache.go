@@ -23,13 +23,18 @@ package cache
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"errors"
 	"p86l/configs"
 	"p86l/internal/debug"
+	"p86l/internal/download"
+	"p86l/internal/manifest"
+	"p86l/internal/news"
+	"p86l/internal/release"
+	"sync/atomic"
 	"time"
 
-	"github.com/google/go-github/v69/github"
 	"github.com/quasilyte/gdata/v2"
 	"github.com/rs/zerolog/log"
 )
@@ -37,12 +42,51 @@ import (
 type Changelog struct {
 	Body      string
 	URL       string
+	Tag       string
+	Channel   release.Channel
 	Timestamp time.Time
 	ExpiresIn time.Duration
 }
 
+func (c *Changelog) Expired() bool {
+	return time.Since(c.Timestamp) > c.ExpiresIn
+}
+
+// Matches reports whether the changelog was fetched for channel.
+func (c *Changelog) Matches(channel release.Channel, pinnedTag string) bool {
+	if channel == "" {
+		channel = release.ChannelStable
+	}
+	if c.Channel != channel {
+		return false
+	}
+	return channel != release.ChannelPinned || c.Tag == pinnedTag
+}
+
+// Links is the last list fetched from configs.LinksURL.
+type Links struct {
+	Links     []configs.Link
+	Timestamp time.Time
+}
+
+// Feed is the last fetch of the news feed at Source.
+type Feed struct {
+	Source    string
+	Items     []news.Item
+	Timestamp time.Time
+	ExpiresIn time.Duration
+}
+
+func (f *Feed) Expired() bool {
+	return time.Since(f.Timestamp) > f.ExpiresIn
+}
+
 type Cache struct {
 	Changelog *Changelog
+	Mirrors   *download.Stats
+	links     atomic.Pointer[Links]
+	feed      atomic.Pointer[Feed]
+	manifest  atomic.Pointer[manifest.Manifest]
 
 	GDataM *gdata.Manager
 }
@@ -62,48 +106,196 @@ func (c *Cache) saveChangelog(appDebug *debug.Debug) *debug.Error {
 	}
 }
 
-func (c *Cache) InitChangelog(appDebug *debug.Debug, githubClient *github.Client, context context.Context) *debug.Error {
-	if c.GDataM.ObjectPropExists(configs.Cache, configs.ChangelogFile) {
-		changelogJSON, err := c.GDataM.LoadObjectProp(configs.Cache, configs.ChangelogFile)
-		if err != nil {
-			return appDebug.New(err, debug.CacheError, debug.ErrChangelogLoad)
-		}
-		changelogData := &Changelog{}
-		err = json.Unmarshal(changelogJSON, &changelogData)
-		if err != nil {
-			return appDebug.New(err, debug.CacheError, debug.ErrChangelogLoad)
-		}
-		c.Changelog = changelogData
-	} else {
-		changelogData, _err := c.RequestChangelog(githubClient, context)
-		if _err != nil {
-			return appDebug.New(_err, debug.NetworkError, debug.ErrChangelogNetwork)
-		}
-		c.Changelog = &changelogData
+// LoadChangelog reads the cached changelog, if any, without touching the
+// network.
+func (c *Cache) LoadChangelog(appDebug *debug.Debug) *debug.Error {
+	if !c.GDataM.ObjectPropExists(configs.Cache, configs.ChangelogFile) {
+		return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	changelogJSON, err := c.GDataM.LoadObjectProp(configs.Cache, configs.ChangelogFile)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrChangelogLoad)
+	}
+	changelogData := &Changelog{}
+	err = json.Unmarshal(changelogJSON, &changelogData)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrChangelogLoad)
+	}
+	c.Changelog = changelogData
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
 
-		err := c.saveChangelog(appDebug)
-		if err != nil {
+// InitChangelog loads the cached changelog and fetches a new one when it is
+// missing, expired or was cached for another channel.
+func (c *Cache) InitChangelog(appDebug *debug.Debug, source release.ReleaseSource, context context.Context, channel release.Channel, pinnedTag string) *debug.Error {
+	if c.Changelog == nil {
+		if err := c.LoadChangelog(appDebug); err.Err != nil {
 			return err
 		}
 	}
+	if c.Changelog != nil && !c.Changelog.Expired() && c.Changelog.Matches(channel, pinnedTag) {
+		return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
 
-	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	changelogData, _err := c.RequestChangelog(source, context, channel, pinnedTag)
+	if _err != nil {
+		return appDebug.New(_err, debug.NetworkError, debug.ErrChangelogNetwork)
+	}
+	c.Changelog = &changelogData
+
+	return c.saveChangelog(appDebug)
 }
 
-func (c *Cache) RequestChangelog(githubClient *github.Client, context context.Context) (Changelog, error) {
+func (c *Cache) RequestChangelog(source release.ReleaseSource, context context.Context, channel release.Channel, pinnedTag string) (Changelog, error) {
 	changelogData := Changelog{}
 
-	release, _, err := githubClient.Repositories.GetLatestRelease(context, configs.RepoOwner, configs.RepoName)
+	if channel == "" {
+		channel = release.ChannelStable
+	}
+	latest, err := release.Resolve(context, source, channel, pinnedTag)
 	if err != nil {
 		return changelogData, err
 	}
 
 	log.Info().Msg("INTERNET CALL")
 
-	changelogData.Body = release.GetBody()
-	changelogData.URL = release.GetHTMLURL()
+	changelogData.Body = latest.Body
+	changelogData.URL = latest.URL
+	changelogData.Tag = latest.Tag
+	changelogData.Channel = channel
 	changelogData.Timestamp = time.Now()
 	changelogData.ExpiresIn = time.Hour
 
 	return changelogData, nil
 }
+
+// LoadMirrors reads the mirror stats, starting empty when there are none.
+func (c *Cache) LoadMirrors(appDebug *debug.Debug) *debug.Error {
+	mirrors := map[string]download.MirrorStats{}
+	if c.GDataM.ObjectPropExists(configs.Cache, configs.MirrorsFile) {
+		mirrorsJSON, err := c.GDataM.LoadObjectProp(configs.Cache, configs.MirrorsFile)
+		if err != nil {
+			return appDebug.New(err, debug.CacheError, debug.ErrMirrorsLoad)
+		}
+		if err := json.Unmarshal(mirrorsJSON, &mirrors); err != nil {
+			return appDebug.New(err, debug.CacheError, debug.ErrMirrorsLoad)
+		}
+	}
+	c.Mirrors = download.NewStats(mirrors)
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (c *Cache) SaveMirrors(appDebug *debug.Debug) *debug.Error {
+	if c.Mirrors == nil {
+		return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	mirrorsBytes, err := json.Marshal(c.Mirrors.Snapshot())
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrMirrorsSave)
+	}
+	if err := c.GDataM.SaveObjectProp(configs.Cache, configs.MirrorsFile, mirrorsBytes); err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrMirrorsSave)
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// Links returns the fetched links, or nil before they were fetched once.
+func (c *Cache) Links() *Links {
+	return c.links.Load()
+}
+
+// LoadLinks reads the links fetched during an earlier run, if any.
+func (c *Cache) LoadLinks(appDebug *debug.Debug) *debug.Error {
+	if !c.GDataM.ObjectPropExists(configs.Cache, configs.LinksFile) {
+		return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	linksJSON, err := c.GDataM.LoadObjectProp(configs.Cache, configs.LinksFile)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrLinksLoad)
+	}
+	links := &Links{}
+	if err := json.Unmarshal(linksJSON, links); err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrLinksLoad)
+	}
+	c.links.Store(links)
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (c *Cache) SaveLinks(appDebug *debug.Debug, links *Links) *debug.Error {
+	linksBytes, err := json.Marshal(links)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrLinksSave)
+	}
+	if err := c.GDataM.SaveObjectProp(configs.Cache, configs.LinksFile, linksBytes); err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrLinksSave)
+	}
+	c.links.Store(links)
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// Feed returns the cached news feed, or nil when there is none.
+func (c *Cache) Feed() *Feed {
+	return c.feed.Load()
+}
+
+// LoadFeed reads the news feed cached during an earlier run, if any.
+func (c *Cache) LoadFeed(appDebug *debug.Debug) *debug.Error {
+	if !c.GDataM.ObjectPropExists(configs.Cache, configs.FeedFile) {
+		return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	feedJSON, err := c.GDataM.LoadObjectProp(configs.Cache, configs.FeedFile)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrFeedLoad)
+	}
+	feed := &Feed{}
+	if err := json.Unmarshal(feedJSON, feed); err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrFeedLoad)
+	}
+	c.feed.Store(feed)
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (c *Cache) SaveFeed(appDebug *debug.Debug, feed *Feed) *debug.Error {
+	feedBytes, err := json.Marshal(feed)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrFeedSave)
+	}
+	if err := c.GDataM.SaveObjectProp(configs.Cache, configs.FeedFile, feedBytes); err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrFeedSave)
+	}
+	c.feed.Store(feed)
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// Manifest returns the verified launcher manifest, or nil when there is
+// none.
+func (c *Cache) Manifest() *manifest.Manifest {
+	return c.manifest.Load()
+}
+
+// LoadManifest reads the signed launcher manifest fetched during an earlier
+// run, if any, and verifies it again with key.
+func (c *Cache) LoadManifest(appDebug *debug.Debug, key ed25519.PublicKey) *debug.Error {
+	if !c.GDataM.ObjectPropExists(configs.Cache, configs.LauncherManifestFile) {
+		return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	}
+	signed, err := c.GDataM.LoadObjectProp(configs.Cache, configs.LauncherManifestFile)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrLauncherManifestLoad)
+	}
+	m, err := manifest.Verify(signed, key)
+	if err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrLauncherManifestInvalid)
+	}
+	c.manifest.Store(m)
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// SaveManifest keeps signed, the document m was verified from.
+func (c *Cache) SaveManifest(appDebug *debug.Debug, m *manifest.Manifest, signed []byte) *debug.Error {
+	if err := c.GDataM.SaveObjectProp(configs.Cache, configs.LauncherManifestFile, signed); err != nil {
+		return appDebug.New(err, debug.CacheError, debug.ErrLauncherManifestSave)
+	}
+	c.manifest.Store(m)
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
@@ -26,6 +26,7 @@ import (
 	"p86l/configs"
 	"p86l/internal/debug"
 	"strconv"
+	"sync"
 
 	"github.com/hajimehoshi/guigui"
 	"github.com/quasilyte/gdata/v2"
@@ -37,6 +38,21 @@ type Data struct {
 
 	ColorMode guigui.ColorMode
 	AppScale  int
+	// Downloads and News are guarded by the app, which owns their queue
+	// and read state.
+	Downloads Downloads
+	News      News
+
+	// network is read by every request, so it is only reached through
+	// Network and SaveNetwork.
+	networkMu sync.RWMutex
+	network   Network
+
+	// instances are shared by the window, the download queue and forwarded
+	// launches, so they are only reached through the methods holding
+	// instancesMu.
+	instancesMu sync.RWMutex
+	instances   []*Instance
 }
 
 func (d *Data) saveColorMode(appDebug *debug.Debug) *debug.Error {
@@ -135,10 +151,23 @@ func (d *Data) UpdateData(context *guigui.Context, appDebug *debug.Debug) *debug
 	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
 }
 
+// HandleDataReset restores the settings after the data folder went away.
+// Downloads and News are left to the app, which holds their locks.
 func (d *Data) HandleDataReset(appDebug *debug.Debug) *debug.Error {
 	d.ColorMode = guigui.ColorModeLight
 	d.AppScale = 2
 
+	d.networkMu.Lock()
+	d.network = Network{}
+	d.networkMu.Unlock()
+
+	d.instancesMu.Lock()
+	d.instances = []*Instance{{Name: configs.DefaultInstance}}
+	err := d.saveInstances(appDebug)
+	d.instancesMu.Unlock()
+	if err.Err != nil {
+		return err
+	}
 	if err := d.saveColorMode(appDebug); err.Err != nil {
 		return err
 	}
@@ -0,0 +1,93 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"p86l/configs"
+	"p86l/internal/debug"
+	"p86l/internal/download"
+)
+
+type Downloads struct {
+	// Limit caps the combined download speed in KiB/s. Zero is unlimited.
+	Limit int64 `json:",omitempty"`
+	// Connections is how many parallel connections an asset is fetched
+	// over. Zero uses configs.DownloadConnections.
+	Connections int `json:",omitempty"`
+	// ScheduleStart and ScheduleEnd, as "15:04", restrict downloads to a
+	// daily window. Both empty allow downloads at any time.
+	ScheduleStart string `json:",omitempty"`
+	ScheduleEnd   string `json:",omitempty"`
+	Paused        bool   `json:",omitempty"`
+	// Queue holds the instances waiting to be installed or updated, in
+	// order.
+	Queue []string `json:",omitempty"`
+}
+
+// DownloadConnections returns the number of parallel connections to use.
+func (d *Downloads) DownloadConnections() int {
+	if d.Connections == 0 {
+		return configs.DownloadConnections
+	}
+	return d.Connections
+}
+
+// Schedule returns the download window.
+func (d *Downloads) Schedule() (download.Schedule, error) {
+	return download.ParseSchedule(d.ScheduleStart, d.ScheduleEnd)
+}
+
+func (d *Data) SaveDownloads(appDebug *debug.Debug) *debug.Error {
+	if d.Downloads.Limit < 0 {
+		return appDebug.New(errors.New("download limit must not be negative"), debug.DataError, debug.ErrDownloadsInvalid)
+	}
+	if d.Downloads.Connections < 0 || d.Downloads.Connections > configs.MaxDownloadConnections {
+		return appDebug.New(fmt.Errorf("connections must be between 1 and %d", configs.MaxDownloadConnections), debug.DataError, debug.ErrDownloadsInvalid)
+	}
+	if _, err := d.Downloads.Schedule(); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrDownloadsInvalid)
+	}
+	downloadsBytes, err := json.Marshal(d.Downloads)
+	if err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrDownloadsSave)
+	}
+	if err := d.GDataM.SaveObjectProp(configs.Data, configs.DownloadsFile, downloadsBytes); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrDownloadsSave)
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (d *Data) InitDownloads(appDebug *debug.Debug) *debug.Error {
+	if d.GDataM.ObjectPropExists(configs.Data, configs.DownloadsFile) {
+		downloadsJSON, err := d.GDataM.LoadObjectProp(configs.Data, configs.DownloadsFile)
+		if err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrDownloadsLoad)
+		}
+		if err := json.Unmarshal(downloadsJSON, &d.Downloads); err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrDownloadsLoad)
+		}
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
@@ -0,0 +1,277 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"p86l/configs"
+	"p86l/internal/debug"
+	"p86l/internal/release"
+	"p86l/internal/runner"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type Instance struct {
+	Name string
+	// Tag is the installed release, empty when nothing is installed.
+	Tag string `json:",omitempty"`
+	// AssetPatterns replaces configs.AssetRules for this instance.
+	AssetPatterns []string `json:",omitempty"`
+
+	Channel   release.Channel `json:",omitempty"`
+	PinnedTag string          `json:",omitempty"`
+	// Dir installs the instance there instead of the games directory.
+	Dir string `json:",omitempty"`
+	// Runner starts Windows builds through Wine or Proton; nil runs the
+	// native build.
+	Runner *runner.Runner `json:",omitempty"`
+	// Profiles are the launch options to pick from. Profile names the one
+	// launches use, none when empty.
+	Profiles []*LaunchProfile `json:",omitempty"`
+	Profile  string           `json:",omitempty"`
+}
+
+// LaunchProfile holds the options a launch adds to the game command.
+type LaunchProfile struct {
+	Name string
+	Args []string          `json:",omitempty"`
+	Env  map[string]string `json:",omitempty"`
+	// Dir replaces the instance directory as the working directory.
+	Dir string `json:",omitempty"`
+	// PreLaunch is a shell command run before the game starts. The launch
+	// stops when it fails.
+	PreLaunch string `json:",omitempty"`
+	// PostExit is a shell command run after the game exits.
+	PostExit string `json:",omitempty"`
+}
+
+// LaunchProfile returns the profile called name, or the selected one when
+// name is empty. It returns nil when there is none.
+func (i *Instance) LaunchProfile(name string) *LaunchProfile {
+	if name == "" {
+		name = i.Profile
+	}
+	for _, profile := range i.Profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	return nil
+}
+
+func (i *Instance) validate() error {
+	if i.Channel != "" && !slices.Contains(release.Channels, i.Channel) {
+		return fmt.Errorf("unknown release channel %q", i.Channel)
+	}
+	if i.Channel == release.ChannelPinned && i.PinnedTag == "" {
+		return fmt.Errorf("pinned channel without a tag")
+	}
+	if err := i.Runner.Validate(); err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, profile := range i.Profiles {
+		if profile.Name == "" || names[profile.Name] {
+			return fmt.Errorf("launch profile name %q is empty or used twice", profile.Name)
+		}
+		names[profile.Name] = true
+		if profile.Dir != "" && !filepath.IsAbs(profile.Dir) {
+			return fmt.Errorf("working directory %q is not an absolute path", profile.Dir)
+		}
+		if err := runner.ValidateEnv(profile.Env); err != nil {
+			return err
+		}
+	}
+	if i.Profile != "" && !names[i.Profile] {
+		return fmt.Errorf("launch profile %q not found", i.Profile)
+	}
+	return nil
+}
+
+// clone returns a deep copy of i, so changes to it stay local until saved.
+func (i *Instance) clone() *Instance {
+	c := *i
+	c.AssetPatterns = slices.Clone(i.AssetPatterns)
+	if i.Runner != nil {
+		r := *i.Runner
+		r.Env = maps.Clone(i.Runner.Env)
+		c.Runner = &r
+	}
+	c.Profiles = nil
+	for _, profile := range i.Profiles {
+		p := *profile
+		p.Args = slices.Clone(profile.Args)
+		p.Env = maps.Clone(profile.Env)
+		c.Profiles = append(c.Profiles, &p)
+	}
+	return &c
+}
+
+// saveInstances needs instancesMu held.
+func (d *Data) saveInstances(appDebug *debug.Debug) *debug.Error {
+	instancesBytes, err := json.Marshal(d.instances)
+	if err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrInstancesSave)
+	}
+	if err := d.GDataM.SaveObjectProp(configs.Data, configs.InstancesFile, instancesBytes); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrInstancesSave)
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (d *Data) InitInstances(appDebug *debug.Debug) *debug.Error {
+	d.instancesMu.Lock()
+	defer d.instancesMu.Unlock()
+	d.instances = nil
+	if d.GDataM.ObjectPropExists(configs.Data, configs.InstancesFile) {
+		instancesJSON, err := d.GDataM.LoadObjectProp(configs.Data, configs.InstancesFile)
+		if err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrInstancesLoad)
+		}
+		if err := json.Unmarshal(instancesJSON, &d.instances); err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrInstancesLoad)
+		}
+	}
+	if d.instance(configs.DefaultInstance) == nil {
+		d.instances = append(d.instances, &Instance{Name: configs.DefaultInstance})
+	}
+	return d.saveInstances(appDebug)
+}
+
+// instance needs instancesMu held.
+func (d *Data) instance(name string) *Instance {
+	for _, instance := range d.instances {
+		if instance.Name == name {
+			return instance
+		}
+	}
+	return nil
+}
+
+// Instance returns a copy of the instance called name, or nil when there is
+// none. Changes to it are kept by SaveInstance.
+func (d *Data) Instance(name string) *Instance {
+	d.instancesMu.RLock()
+	defer d.instancesMu.RUnlock()
+	if instance := d.instance(name); instance != nil {
+		return instance.clone()
+	}
+	return nil
+}
+
+// Instances returns copies of every instance, in the order they were added.
+func (d *Data) Instances() []*Instance {
+	d.instancesMu.RLock()
+	defer d.instancesMu.RUnlock()
+	instances := make([]*Instance, len(d.instances))
+	for i, instance := range d.instances {
+		instances[i] = instance.clone()
+	}
+	return instances
+}
+
+// AddInstance creates a new instance following the stable channel. Names
+// are used as directory names, so separators and dots are refused.
+func (d *Data) AddInstance(appDebug *debug.Debug, name string) (*Instance, *debug.Error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\:`) {
+		return nil, appDebug.New(fmt.Errorf("invalid instance name %q", name), debug.DataError, debug.ErrInstanceInvalid)
+	}
+	d.instancesMu.Lock()
+	defer d.instancesMu.Unlock()
+	if d.instance(name) != nil {
+		return nil, appDebug.New(fmt.Errorf("instance %q already exists", name), debug.DataError, debug.ErrInstanceInvalid)
+	}
+	instance := &Instance{Name: name, Channel: release.ChannelStable}
+	d.instances = append(d.instances, instance)
+	if err := d.saveInstances(appDebug); err.Err != nil {
+		d.instances = d.instances[:len(d.instances)-1]
+		return nil, err
+	}
+	return instance.clone(), appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// SaveInstance stores a copy of instance, replacing the one with the same
+// name. Use UpdateInstance to change a few fields, as instance may be
+// stale by now.
+func (d *Data) SaveInstance(appDebug *debug.Debug, instance *Instance) *debug.Error {
+	if err := instance.validate(); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrInstanceInvalid)
+	}
+	d.instancesMu.Lock()
+	defer d.instancesMu.Unlock()
+	return d.replaceInstance(appDebug, instance.clone())
+}
+
+// UpdateInstance applies change to a copy of the instance called name and
+// stores it when it is valid, with the instance locked in between.
+func (d *Data) UpdateInstance(appDebug *debug.Debug, name string, change func(instance *Instance)) *debug.Error {
+	d.instancesMu.Lock()
+	defer d.instancesMu.Unlock()
+	existing := d.instance(name)
+	if existing == nil {
+		return appDebug.New(fmt.Errorf("instance %s not found", name), debug.InstallError, debug.ErrInstanceNotFound)
+	}
+	updated := existing.clone()
+	change(updated)
+	if err := updated.validate(); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrInstanceInvalid)
+	}
+	return d.replaceInstance(appDebug, updated)
+}
+
+// replaceInstance needs instancesMu held. The previous instances are kept
+// when saving fails.
+func (d *Data) replaceInstance(appDebug *debug.Debug, instance *Instance) *debug.Error {
+	previous := slices.Clone(d.instances)
+	index := slices.IndexFunc(d.instances, func(i *Instance) bool { return i.Name == instance.Name })
+	if index >= 0 {
+		d.instances[index] = instance
+	} else {
+		d.instances = append(d.instances, instance)
+	}
+	if err := d.saveInstances(appDebug); err.Err != nil {
+		d.instances = previous
+		return err
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// RemoveInstance forgets the instance called name, leaving its files alone.
+// The default instance cannot be removed.
+func (d *Data) RemoveInstance(appDebug *debug.Debug, name string) *debug.Error {
+	if name == configs.DefaultInstance {
+		return appDebug.New(fmt.Errorf("instance %s cannot be removed", name), debug.DataError, debug.ErrInstanceInvalid)
+	}
+	d.instancesMu.Lock()
+	defer d.instancesMu.Unlock()
+	previous := slices.Clone(d.instances)
+	d.instances = slices.DeleteFunc(d.instances, func(i *Instance) bool { return i.Name == name })
+	if err := d.saveInstances(appDebug); err.Err != nil {
+		d.instances = previous
+		return err
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
@@ -0,0 +1,115 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"p86l/configs"
+	"p86l/internal/debug"
+	"p86l/internal/network"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type Network struct {
+	// ProbeURL replaces configs.GitHubProbeURL when set.
+	ProbeURL string `json:",omitempty"`
+	// Proxy is used instead of the HTTP_PROXY and HTTPS_PROXY environment
+	// variables when set.
+	Proxy string `json:",omitempty"`
+	// ReleaseSource replaces the GitHub repository of the game when set. It
+	// is either the URL of a release index or a local folder.
+	ReleaseSource string `json:",omitempty"`
+	// Mirrors are tried after configs.AssetMirrors.
+	Mirrors []string `json:",omitempty"`
+}
+
+func validReleaseSource(source string) error {
+	if source == "" {
+		return nil
+	}
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		_, err := url.Parse(source)
+		return err
+	}
+	if !filepath.IsAbs(source) {
+		return fmt.Errorf("release source %q is neither a URL nor an absolute folder path", source)
+	}
+	return nil
+}
+
+// Network returns a copy of the network settings.
+func (d *Data) Network() Network {
+	d.networkMu.RLock()
+	defer d.networkMu.RUnlock()
+	settings := d.network
+	settings.Mirrors = slices.Clone(settings.Mirrors)
+	return settings
+}
+
+// SaveNetwork validates settings and only swaps them in once they are
+// valid and saved, so requests never see a broken proxy.
+func (d *Data) SaveNetwork(appDebug *debug.Debug, settings Network) *debug.Error {
+	if _, err := network.ParseProxy(settings.Proxy); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrProxyInvalid)
+	}
+	if err := validReleaseSource(settings.ReleaseSource); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrReleaseSourceInvalid)
+	}
+	for _, mirror := range settings.Mirrors {
+		if u, err := url.Parse(mirror); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return appDebug.New(fmt.Errorf("mirror %q is not an HTTP URL", mirror), debug.DataError, debug.ErrMirrorInvalid)
+		}
+	}
+	settings.Mirrors = slices.Clone(settings.Mirrors)
+	networkBytes, err := json.Marshal(settings)
+	if err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrNetworkSave)
+	}
+	d.networkMu.Lock()
+	defer d.networkMu.Unlock()
+	if err := d.GDataM.SaveObjectProp(configs.Data, configs.NetworkFile, networkBytes); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrNetworkSave)
+	}
+	d.network = settings
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (d *Data) InitNetwork(appDebug *debug.Debug) *debug.Error {
+	if d.GDataM.ObjectPropExists(configs.Data, configs.NetworkFile) {
+		networkJSON, err := d.GDataM.LoadObjectProp(configs.Data, configs.NetworkFile)
+		if err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrNetworkLoad)
+		}
+		var settings Network
+		if err := json.Unmarshal(networkJSON, &settings); err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrNetworkLoad)
+		}
+		d.networkMu.Lock()
+		d.network = settings
+		d.networkMu.Unlock()
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
@@ -0,0 +1,73 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"p86l/configs"
+	"p86l/internal/debug"
+	"p86l/internal/news"
+	"path/filepath"
+)
+
+type News struct {
+	// FeedURL replaces configs.NewsFeedURL when set. It is either a URL or
+	// an absolute file path.
+	FeedURL string `json:",omitempty"`
+	// Read holds the IDs of the items opened or marked read.
+	Read []string `json:",omitempty"`
+}
+
+func validFeed(feed string) error {
+	if feed == "" || news.IsRemote(feed) || filepath.IsAbs(feed) {
+		return nil
+	}
+	return fmt.Errorf("news feed %q is neither a web address nor an absolute file path", feed)
+}
+
+func (d *Data) SaveNews(appDebug *debug.Debug) *debug.Error {
+	if err := validFeed(d.News.FeedURL); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrNewsInvalid)
+	}
+	newsBytes, err := json.Marshal(d.News)
+	if err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrNewsSave)
+	}
+	if err := d.GDataM.SaveObjectProp(configs.Data, configs.NewsFile, newsBytes); err != nil {
+		return appDebug.New(err, debug.DataError, debug.ErrNewsSave)
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+func (d *Data) InitNews(appDebug *debug.Debug) *debug.Error {
+	if d.GDataM.ObjectPropExists(configs.Data, configs.NewsFile) {
+		newsJSON, err := d.GDataM.LoadObjectProp(configs.Data, configs.NewsFile)
+		if err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrNewsLoad)
+		}
+		if err := json.Unmarshal(newsJSON, &d.News); err != nil {
+			return appDebug.New(err, debug.DataError, debug.ErrNewsLoad)
+		}
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
@@ -35,12 +35,19 @@ const (
 	NetworkError ErrorType = "network"
 	DataError    ErrorType = "data"
 	CacheError   ErrorType = "cache"
+	InstallError ErrorType = "install"
+	LaunchError  ErrorType = "launch"
+	AuthError    ErrorType = "auth"
 )
 
 const (
 	// App errors (1001-1999)
 	ErrUnknown int = iota + 1001
 	ErrBrowserOpen
+	ErrLauncherUpdateCheck
+	ErrLauncherUpdate
+	ErrLauncherRollback
+	ErrLinkInvalid
 
 	// Filesystem errors (2001-2999)
 	ErrGDataOpenFailed int = iota + 2001
@@ -51,6 +58,8 @@ const (
 	ErrOpenFolderFailed
 	ErrFileNotFound
 	ErrFolderClear
+	ErrDiskUsage
+	ErrFreeSpace
 
 	// Data errors (3001-3999)
 	ErrColorModeLoad int = iota + 3001
@@ -59,12 +68,63 @@ const (
 	ErrAppScaleSave
 	ErrColorModeClear
 	ErrAppScaleClear
+	ErrInstancesLoad
+	ErrInstancesSave
+	ErrInstanceInvalid
+	ErrNetworkLoad
+	ErrNetworkSave
+	ErrProxyInvalid
+	ErrReleaseSourceInvalid
+	ErrMirrorInvalid
+	ErrDownloadsLoad
+	ErrDownloadsSave
+	ErrDownloadsInvalid
+	ErrNewsLoad
+	ErrNewsSave
+	ErrNewsInvalid
 
 	// Cache errors (4001-4999)
 	ErrChangelogLoad int = iota + 4001
 	ErrChangelogSave
 	ErrChangelogClear
 	ErrChangelogNetwork
+	ErrMirrorsLoad
+	ErrMirrorsSave
+	ErrLinksLoad
+	ErrLinksSave
+	ErrLinksNetwork
+	ErrFeedLoad
+	ErrFeedSave
+	ErrFeedNetwork
+	ErrLauncherManifestLoad
+	ErrLauncherManifestSave
+	ErrLauncherManifestNetwork
+	ErrLauncherManifestInvalid
+
+	// Install errors (5001-5999)
+	ErrInsufficientSpace int = iota + 5001
+	ErrReleaseNetwork
+	ErrAssetNotFound
+	ErrDownloadFailed
+	ErrExtractFailed
+	ErrInstanceNotFound
+	ErrManifestSave
+	ErrVerifyFailed
+	ErrLauncherOutdated
+	ErrInstanceDirInUse
+
+	// Launch errors (6001-6999)
+	ErrGameNotFound int = iota + 6001
+	ErrGameLaunchFailed
+	ErrMissingDependency
+	ErrPrefixFailed
+	ErrLaunchHookFailed
+
+	// Auth errors (7001-7999)
+	ErrTokenLoad int = iota + 7001
+	ErrTokenSave
+	ErrTokenInvalid
+	ErrRateLimitCheck
 )
 
 type Error struct {
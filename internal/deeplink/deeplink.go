@@ -0,0 +1,161 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package deeplink parses the p86l:// links that websites use to open the
+// launcher, and registers the scheme with the system.
+package deeplink
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"p86l/configs"
+	"p86l/internal/release"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalid is wrapped by every Parse error.
+var ErrInvalid = errors.New("invalid link")
+
+type Action string
+
+const (
+	// ActionInstall opens an instance for install, optionally switching it
+	// to another channel: p86l://install?channel=beta&instance=<name>.
+	ActionInstall Action = "install"
+	// ActionLaunch starts an installed instance: p86l://launch/<instance>.
+	ActionLaunch Action = "launch"
+	// ActionChangelog shows the notes of a release: p86l://changelog/<tag>.
+	ActionChangelog Action = "changelog"
+)
+
+type Link struct {
+	Action   Action
+	Instance string
+	Channel  release.Channel
+	Tag      string
+}
+
+// IsLink reports whether arg looks like a deep link, valid or not.
+func IsLink(arg string) bool {
+	return strings.HasPrefix(strings.ToLower(arg), configs.LinkScheme+":")
+}
+
+// Parse validates raw. The instance defaults to configs.DefaultInstance.
+func Parse(raw string) (*Link, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if !strings.EqualFold(u.Scheme, configs.LinkScheme) {
+		return nil, fmt.Errorf("%w: scheme %q", ErrInvalid, u.Scheme)
+	}
+	if u.Opaque != "" {
+		return nil, fmt.Errorf("%w: %s is not of the form %s://<action>", ErrInvalid, raw, configs.LinkScheme)
+	}
+	// Browsers may add a trailing slash to p86l://install.
+	path := strings.Trim(u.Path, "/")
+
+	link := &Link{Action: Action(strings.ToLower(u.Host)), Instance: configs.DefaultInstance}
+	query := u.Query()
+	switch link.Action {
+	case ActionInstall:
+		if path != "" {
+			return nil, fmt.Errorf("%w: unexpected path %q", ErrInvalid, path)
+		}
+		if instance := query.Get("instance"); instance != "" {
+			link.Instance = instance
+		}
+		link.Tag = query.Get("tag")
+		if channel := query.Get("channel"); channel != "" {
+			link.Channel = release.Channel(strings.ToLower(channel))
+			if !slices.Contains(release.Channels, link.Channel) {
+				return nil, fmt.Errorf("%w: unknown channel %q", ErrInvalid, channel)
+			}
+		}
+		if link.Tag != "" && link.Channel == "" {
+			link.Channel = release.ChannelPinned
+		}
+		if (link.Channel == release.ChannelPinned) != (link.Tag != "") {
+			return nil, fmt.Errorf("%w: a tag goes with the pinned channel", ErrInvalid)
+		}
+	case ActionLaunch:
+		if path != "" {
+			link.Instance = path
+		}
+	case ActionChangelog:
+		link.Tag = path
+		if link.Tag == "" {
+			return nil, fmt.Errorf("%w: changelog needs a tag", ErrInvalid)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", ErrInvalid, u.Host)
+	}
+
+	if err := validName(link.Instance); err != nil {
+		return nil, fmt.Errorf("%w: instance: %v", ErrInvalid, err)
+	}
+	if link.Tag != "" {
+		if err := validName(link.Tag); err != nil {
+			return nil, fmt.Errorf("%w: tag: %v", ErrInvalid, err)
+		}
+	}
+	return link, nil
+}
+
+// validName refuses names that could leave a directory or hide in the
+// interface, as links come from outside.
+func validName(name string) error {
+	if name == "" || name == "." || name == ".." || len(name) > 128 || strings.TrimSpace(name) != name {
+		return fmt.Errorf("%q is not a valid name", name)
+	}
+	for _, r := range name {
+		if strings.ContainsRune(`/\:`, r) || !unicode.IsPrint(r) {
+			return fmt.Errorf("%q is not a valid name", name)
+		}
+	}
+	return nil
+}
+
+func (l *Link) String() string {
+	u := url.URL{Scheme: configs.LinkScheme, Host: string(l.Action)}
+	switch l.Action {
+	case ActionInstall:
+		query := url.Values{}
+		if l.Instance != configs.DefaultInstance {
+			query.Set("instance", l.Instance)
+		}
+		if l.Channel != "" {
+			query.Set("channel", string(l.Channel))
+		}
+		if l.Tag != "" {
+			query.Set("tag", l.Tag)
+		}
+		u.RawQuery = query.Encode()
+	case ActionLaunch:
+		u.Path = "/" + l.Instance
+	case ActionChangelog:
+		u.Path = "/" + l.Tag
+	}
+	return u.String()
+}
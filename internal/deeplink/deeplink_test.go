@@ -0,0 +1,115 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deeplink
+
+import (
+	"errors"
+	"p86l/configs"
+	"p86l/internal/release"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Link
+	}{
+		{"p86l://install", Link{Action: ActionInstall, Instance: configs.DefaultInstance}},
+		{"P86L://Install/", Link{Action: ActionInstall, Instance: configs.DefaultInstance}},
+		{"p86l://install?channel=BETA&instance=test", Link{Action: ActionInstall, Instance: "test", Channel: release.ChannelBeta}},
+		{"p86l://install?tag=v1.2.0", Link{Action: ActionInstall, Instance: configs.DefaultInstance, Channel: release.ChannelPinned, Tag: "v1.2.0"}},
+		{"p86l://launch", Link{Action: ActionLaunch, Instance: configs.DefaultInstance}},
+		{"p86l://launch/my%20game", Link{Action: ActionLaunch, Instance: "my game"}},
+		{"p86l://changelog/v1.2.0", Link{Action: ActionChangelog, Instance: configs.DefaultInstance, Tag: "v1.2.0"}},
+		{"p86l://install?instance=" + strings.Repeat("a", 128), Link{Action: ActionInstall, Instance: strings.Repeat("a", 128)}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.raw)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, *got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, raw := range []string{
+		// Not a link of ours.
+		"https://example.com/install",
+		"p86l:install",
+		"p86l://",
+		"p86l://%zz",
+		// Unknown actions.
+		"p86l://uninstall",
+		"p86l://open/default",
+		"p86l://install/extra",
+		"p86l://changelog",
+		// Instance names leaving the instances directory.
+		"p86l://launch/..",
+		"p86l://launch/../other",
+		"p86l://launch/..%2Fother",
+		"p86l://launch/a%5Cb",
+		"p86l://launch/C:",
+		"p86l://install?instance=..",
+		"p86l://install?instance=.",
+		"p86l://install?instance=..%5C..%5Cwindows",
+		"p86l://install?instance=%2Fetc",
+		"p86l://changelog/..%2F..%2Fsecret",
+		// Over-long or odd query values.
+		"p86l://install?instance=" + strings.Repeat("a", 129),
+		"p86l://install?tag=" + strings.Repeat("v", 129),
+		"p86l://install?instance=%00",
+		"p86l://install?instance=a%0Ab",
+		"p86l://install?instance=%E2%80%AEexe.txt",
+		"p86l://install?instance=%20padded",
+		"p86l://install?channel=nightly",
+		"p86l://install?channel=stable&tag=v1.2.0",
+		"p86l://install?channel=pinned",
+	} {
+		if link, err := Parse(raw); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) = %+v, %v, want %v", raw, link, err, ErrInvalid)
+		}
+	}
+}
+
+func TestLinkStringRoundTrip(t *testing.T) {
+	for _, link := range []Link{
+		{Action: ActionInstall, Instance: configs.DefaultInstance},
+		{Action: ActionInstall, Instance: "two words", Channel: release.ChannelBeta},
+		{Action: ActionInstall, Instance: "a&b=c", Channel: release.ChannelPinned, Tag: "v1.2.0"},
+		{Action: ActionLaunch, Instance: "my game"},
+		{Action: ActionChangelog, Instance: configs.DefaultInstance, Tag: "v1.2.0"},
+	} {
+		got, err := Parse(link.String())
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", link.String(), err)
+			continue
+		}
+		if *got != link {
+			t.Errorf("Parse(%q) = %+v, want %+v", link.String(), *got, link)
+		}
+	}
+}
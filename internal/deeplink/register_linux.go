@@ -0,0 +1,80 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deeplink
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"p86l/configs"
+	"path/filepath"
+	"strings"
+)
+
+// Register writes a desktop entry handling the link scheme for exe and
+// makes it the default handler. An AppImage registers the image rather
+// than its temporary mount.
+func Register(exe string) error {
+	if image := os.Getenv("APPIMAGE"); image != "" {
+		exe = image
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	dir := filepath.Join(dataHome, "applications")
+	path := filepath.Join(dir, configs.DesktopEntry)
+
+	// Exec arguments are quoted first, then escaped again as a string.
+	quoted := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", "$", `\$`).Replace(exe)
+	quoted = strings.ReplaceAll(quoted, `\`, `\\`)
+	entry := []byte(fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=Project 86 Launcher
+Exec="%s" %%u
+Terminal=false
+Categories=Game;
+MimeType=x-scheme-handler/%s;
+`, quoted, configs.LinkScheme))
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, entry) {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, entry, 0644); err != nil {
+		return err
+	}
+	// Both tools are optional; without them the entry is still picked up
+	// once the desktop rescans.
+	exec.Command("update-desktop-database", dir).Run()
+	if out, err := exec.Command("xdg-mime", "default", configs.DesktopEntry, "x-scheme-handler/"+configs.LinkScheme).CombinedOutput(); err != nil {
+		return fmt.Errorf("xdg-mime: %v: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package deps checks that the shared libraries a Linux game build links
+// against are installed, before it is launched.
+package deps
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"p86l/configs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var ErrNoLibc = errors.New("glibc version unknown")
+
+// Missing is a dependency none of whose libraries were found, with the
+// command that installs it on this distribution, if known.
+type Missing struct {
+	Dependency configs.Dependency
+	Hint       string
+}
+
+func (m Missing) String() string {
+	return fmt.Sprintf("%s (%s)", m.Dependency.Name, strings.Join(m.Dependency.Libraries, " or "))
+}
+
+// System is where libraries are looked up.
+type System struct {
+	// Ldconfig returns the "ldconfig -p" listing of the linker cache.
+	Ldconfig func() ([]byte, error)
+	// Dirs are probed for libraries when there is no linker cache, as on
+	// NixOS.
+	Dirs []string
+	// OSRelease is the os-release file naming the distribution.
+	OSRelease string
+	// Arch is the GOARCH the game was built for.
+	Arch string
+}
+
+// Default returns the running system.
+func Default(arch string) *System {
+	dirs := filepath.SplitList(os.Getenv("LD_LIBRARY_PATH"))
+	dirs = append(dirs,
+		"/lib64", "/usr/lib64", "/lib", "/usr/lib",
+		"/lib/x86_64-linux-gnu", "/usr/lib/x86_64-linux-gnu",
+		"/lib/aarch64-linux-gnu", "/usr/lib/aarch64-linux-gnu",
+		"/run/opengl-driver/lib",
+	)
+	return &System{
+		Ldconfig: func() ([]byte, error) {
+			// ldconfig lives in sbin, which is not always on PATH.
+			path, err := exec.LookPath("ldconfig")
+			if err != nil {
+				path = "/sbin/ldconfig"
+			}
+			return exec.Command(path, "-p").Output()
+		},
+		Dirs:      dirs,
+		OSRelease: "/etc/os-release",
+		Arch:      arch,
+	}
+}
+
+// ldconfigArch is how "ldconfig -p" marks the libraries of an architecture.
+var ldconfigArch = map[string]string{
+	"amd64": "x86-64",
+	"arm64": "AArch64",
+}
+
+// cached parses the linker cache into the set of library names built for
+// the architecture.
+func (s *System) cached(listing []byte) map[string]bool {
+	libs := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(listing))
+	for scanner.Scan() {
+		// "\tlibz.so.1 (libc6,x86-64) => /lib/x86_64-linux-gnu/libz.so.1"
+		name, rest, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " (")
+		if !ok {
+			continue
+		}
+		flags, _, ok := strings.Cut(rest, ")")
+		if !ok {
+			continue
+		}
+		if arch, known := ldconfigArch[s.Arch]; known && !slices.Contains(strings.Split(flags, ","), arch) {
+			continue
+		}
+		libs[name] = true
+	}
+	return libs
+}
+
+func (s *System) probe(library string) bool {
+	for _, dir := range s.Dirs {
+		if dir == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, library)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the dependencies that are missing. The linker cache is
+// used when ldconfig runs, the library directories otherwise.
+func (s *System) Check(dependencies []configs.Dependency) []Missing {
+	found := s.probe
+	if listing, err := s.Ldconfig(); err == nil {
+		libs := s.cached(listing)
+		found = func(library string) bool {
+			return libs[library] || s.probe(library)
+		}
+	}
+
+	var ids []string
+	var missing []Missing
+	for _, dependency := range dependencies {
+		if slices.ContainsFunc(dependency.Libraries, found) {
+			continue
+		}
+		if ids == nil {
+			ids = s.distribution()
+		}
+		m := Missing{Dependency: dependency}
+		for _, id := range ids {
+			if hint, ok := dependency.Hints[id]; ok {
+				m.Hint = hint
+				break
+			}
+		}
+		missing = append(missing, m)
+	}
+	return missing
+}
+
+// distribution returns the os-release ID followed by the IDs it derives
+// from, most specific first.
+func (s *System) distribution() []string {
+	b, err := os.ReadFile(s.OSRelease)
+	if err != nil {
+		return []string{}
+	}
+	var id, like string
+	for _, line := range strings.Split(string(b), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			id = value
+		case "ID_LIKE":
+			like = value
+		}
+	}
+	return append([]string{id}, strings.Fields(like)...)
+}
+
+// GlibcVersion returns the version of the C library, such as "2.36".
+func GlibcVersion() (string, error) {
+	out, err := exec.Command("getconf", "GNU_LIBC_VERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNoLibc, err)
+	}
+	// "glibc 2.36"
+	name, version, ok := strings.Cut(strings.TrimSpace(string(out)), " ")
+	if !ok || name != "glibc" {
+		return "", fmt.Errorf("%w: %q", ErrNoLibc, out)
+	}
+	return version, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package deps
+
+import (
+	"errors"
+	"os"
+	"p86l/configs"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const ldconfigListing = `4 libs found in cache ` + "`/etc/ld.so.cache'" + `
+	libz.so.1 (libc6,x86-64) => /lib/x86_64-linux-gnu/libz.so.1
+	libz.so.1 (libc6) => /lib/i386-linux-gnu/libz.so.1
+	libGL.so.1 (libc6,AArch64) => /lib/aarch64-linux-gnu/libGL.so.1
+	libX11.so.6 (libc6,x86-64) => /lib/x86_64-linux-gnu/libX11.so.6
+`
+
+var dependencies = []configs.Dependency{
+	{Name: "zlib", Libraries: []string{"libz.so.1"}, Hints: map[string]string{"debian": "apt install zlib1g"}},
+	{Name: "OpenGL", Libraries: []string{"libGL.so.1"}, Hints: map[string]string{"debian": "apt install libgl1", "fedora": "dnf install mesa-libGL"}},
+	{Name: "X11", Libraries: []string{"libX11.so.6"}},
+	{Name: "Vulkan", Libraries: []string{"libvulkan.so.1", "libvulkan.so"}},
+}
+
+func missingNames(missing []Missing) []string {
+	var names []string
+	for _, m := range missing {
+		names = append(names, m.Dependency.Name)
+	}
+	return names
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	osRelease := filepath.Join(dir, "os-release")
+	if err := os.WriteFile(osRelease, []byte("ID=ubuntu\nID_LIKE=\"debian\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	libDir := filepath.Join(dir, "lib")
+	if err := os.Mkdir(libDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ldconfig := func() ([]byte, error) { return []byte(ldconfigListing), nil }
+
+	tests := []struct {
+		name      string
+		ldconfig  func() ([]byte, error)
+		arch      string
+		libraries []string
+		want      []string
+	}{
+		{
+			name:     "amd64 cache",
+			ldconfig: ldconfig,
+			arch:     "amd64",
+			want:     []string{"OpenGL", "Vulkan"},
+		},
+		{
+			name:     "arm64 cache",
+			ldconfig: ldconfig,
+			arch:     "arm64",
+			want:     []string{"zlib", "X11", "Vulkan"},
+		},
+		{
+			name:      "library folder next to the cache",
+			ldconfig:  ldconfig,
+			arch:      "amd64",
+			libraries: []string{"libvulkan.so"},
+			want:      []string{"OpenGL"},
+		},
+		{
+			name:      "no cache",
+			ldconfig:  func() ([]byte, error) { return nil, errors.New("no ldconfig") },
+			arch:      "amd64",
+			libraries: []string{"libz.so.1", "libGL.so.1"},
+			want:      []string{"X11", "Vulkan"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(libDir, filepath.Base(t.Name()))
+			if err := os.Mkdir(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			for _, library := range tt.libraries {
+				if err := os.WriteFile(filepath.Join(dir, library), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			s := &System{Ldconfig: tt.ldconfig, Dirs: []string{"", dir}, OSRelease: osRelease, Arch: tt.arch}
+
+			missing := s.Check(dependencies)
+			if got := missingNames(missing); !slices.Equal(got, tt.want) {
+				t.Errorf("Check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckHints(t *testing.T) {
+	dir := t.TempDir()
+	osRelease := filepath.Join(dir, "os-release")
+	if err := os.WriteFile(osRelease, []byte("NAME=\"Nobara\"\nID=nobara\nID_LIKE=\"rhel centos fedora\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := &System{
+		Ldconfig:  func() ([]byte, error) { return nil, nil },
+		OSRelease: osRelease,
+		Arch:      "amd64",
+	}
+	missing := s.Check(dependencies[:2])
+	if len(missing) != 2 {
+		t.Fatalf("Check() = %q, want zlib and OpenGL", missingNames(missing))
+	}
+	if missing[0].Hint != "" {
+		t.Errorf("zlib hint = %q, want none", missing[0].Hint)
+	}
+	if want := "dnf install mesa-libGL"; missing[1].Hint != want {
+		t.Errorf("OpenGL hint = %q, want %q", missing[1].Hint, want)
+	}
+
+	// The distribution only matters for missing dependencies.
+	s.OSRelease = filepath.Join(dir, "missing")
+	s.Ldconfig = func() ([]byte, error) { return []byte(ldconfigListing), nil }
+	if missing := s.Check(dependencies[:1]); len(missing) != 0 {
+		t.Errorf("Check() = %q, want nothing", missingNames(missing))
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"p86l/internal/version"
+	"path/filepath"
+)
+
+// Progress is called while downloading with the bytes received so far and
+// the expected total, which is -1 when the server does not send a length.
+type Progress func(done, total int64)
+
+// Open starts a GET request for url and returns the body with its length,
+// which is -1 when the server does not send one.
+func Open(ctx context.Context, client *http.Client, url string) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// File fetches url into dest. The body is written to a ".part" file first
+// and only renamed to dest once it is complete.
+func File(ctx context.Context, client *http.Client, url, dest string, progress Progress) error {
+	body, total, err := Open(ctx, client, url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return Save(body, total, dest, progress)
+}
+
+// Save writes r, which is expected to hold total bytes, to dest through a
+// ".part" file like File.
+func Save(r io.Reader, total int64, dest string, progress Progress) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	part := dest + ".part"
+	f, err := os.Create(part)
+	if err != nil {
+		return err
+	}
+
+	w := &progressWriter{w: f, total: total, progress: progress}
+	if _, err := io.Copy(w, r); err != nil {
+		f.Close()
+		os.Remove(part)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(part)
+		return err
+	}
+	return os.Rename(part, dest)
+}
+
+type progressWriter struct {
+	w        io.Writer
+	done     int64
+	total    int64
+	progress Progress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	if p.progress != nil {
+		p.progress(p.done, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,316 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrTooSlow          = errors.New("download too slow")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Candidate is one place a file can be downloaded from.
+type Candidate struct {
+	// Key names the mirror in Stats, usually its host.
+	Key string
+	// Open streams the file. It may be nil when URL is set.
+	Open func(ctx context.Context) (io.ReadCloser, int64, error)
+	// URL, when set, is a plain HTTP download that can be fetched in
+	// ranges over several connections with Client.
+	URL    string
+	Client *http.Client
+}
+
+func (c Candidate) client() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
+
+type MirrorStats struct {
+	Successes int
+	Failures  int
+	// Speed is a moving average of the download speed in bytes per second.
+	Speed    float64
+	LastUsed time.Time
+}
+
+// Stats remembers how each mirror performed so the best one is tried
+// first.
+type Stats struct {
+	mu      sync.Mutex
+	mirrors map[string]MirrorStats
+}
+
+func NewStats(mirrors map[string]MirrorStats) *Stats {
+	return &Stats{mirrors: mirrors}
+}
+
+// Snapshot returns a copy of the stats, for saving them.
+func (s *Stats) Snapshot() map[string]MirrorStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mirrors := make(map[string]MirrorStats, len(s.mirrors))
+	for key, stats := range s.mirrors {
+		mirrors[key] = stats
+	}
+	return mirrors
+}
+
+func (s *Stats) record(key string, ok bool, speed float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mirrors == nil {
+		s.mirrors = map[string]MirrorStats{}
+	}
+	stats := s.mirrors[key]
+	if ok {
+		stats.Successes++
+	} else {
+		stats.Failures++
+	}
+	if speed > 0 {
+		if stats.Speed == 0 {
+			stats.Speed = speed
+		} else {
+			stats.Speed = 0.7*stats.Speed + 0.3*speed
+		}
+	}
+	stats.LastUsed = time.Now()
+	s.mirrors[key] = stats
+}
+
+// Order sorts candidates by their measured speed weighted by how often they
+// succeeded. Mirrors that never worked come last, by number of failures,
+// and ties keep their order.
+func (s *Stats) Order(candidates []Candidate) []Candidate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	score := func(c Candidate) float64 {
+		stats := s.mirrors[c.Key]
+		return stats.Speed * float64(stats.Successes) / float64(stats.Successes+stats.Failures+1)
+	}
+	ordered := slices.Clone(candidates)
+	slices.SortStableFunc(ordered, func(a, b Candidate) int {
+		if sa, sb := score(a), score(b); sa != sb {
+			if sa > sb {
+				return -1
+			}
+			return 1
+		}
+		return s.mirrors[a.Key].Failures - s.mirrors[b.Key].Failures
+	})
+	return ordered
+}
+
+type FailoverOptions struct {
+	// SHA256 is the expected digest of the file. Every candidate is checked
+	// against it so the result does not depend on the mirror.
+	SHA256 string
+	// MinSpeed, in bytes per second, is the average below which a candidate
+	// is dropped after SlowWindow. The last candidate is never dropped for
+	// being slow.
+	MinSpeed   int64
+	SlowWindow time.Duration
+	Stats      *Stats
+	// Throttle, when set, paces the download. Time spent held by it does
+	// not count as slow.
+	Throttle *Throttle
+	// Connections is how many range requests a candidate with a URL is
+	// fetched over. Files under two MinPartSize parts, and servers that
+	// do not send "Accept-Ranges: bytes", use a single stream.
+	Connections int
+	MinPartSize int64
+	Progress    Progress
+}
+
+// Failover downloads dest from the first candidate that works, moving to
+// the next one on errors, low throughput or a checksum mismatch. The ranges
+// a candidate fetched before failing are kept, and the next candidate that
+// serves ranges of the same size only fetches the rest.
+func Failover(ctx context.Context, candidates []Candidate, dest string, opts FailoverOptions) error {
+	if opts.Stats != nil {
+		candidates = opts.Stats.Order(candidates)
+	}
+	resume := &partial{}
+	defer func() {
+		if len(resume.missing) > 0 {
+			os.Remove(dest + ".part")
+		}
+	}()
+	var errs []error
+	for i, candidate := range candidates {
+		start := time.Now()
+		n, err := fetch(ctx, candidate, dest, opts, resume, i < len(candidates)-1)
+		speed := float64(n) / max(time.Since(start).Seconds(), 0.001)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if opts.Stats != nil {
+			opts.Stats.record(candidate.Key, err == nil, speed)
+		}
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", candidate.Key, err))
+	}
+	if len(errs) == 0 {
+		return errors.New("no download source")
+	}
+	return errors.Join(errs...)
+}
+
+func fetch(ctx context.Context, candidate Candidate, dest string, opts FailoverOptions, resume *partial, dropSlow bool) (int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var received atomic.Int64
+	minSpeed := opts.MinSpeed
+	if opts.Throttle != nil {
+		// A low speed cap must not make every mirror look slow.
+		if limit := opts.Throttle.Limit(); limit > 0 {
+			minSpeed = min(minSpeed, limit/2)
+		}
+	}
+	wrap := func(r io.Reader) io.Reader {
+		r = &countingReader{r: r, n: &received}
+		if opts.Throttle != nil {
+			r = opts.Throttle.Reader(ctx, r)
+		}
+		return r
+	}
+
+	var tooSlow atomic.Bool
+	if dropSlow && minSpeed > 0 && opts.SlowWindow > 0 {
+		ticker := time.NewTicker(opts.SlowWindow)
+		defer ticker.Stop()
+		go func() {
+			var last int64
+			windowStart := time.Now()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					n := received.Load()
+					// Windows in which the throttle held the download
+					// are skipped.
+					held := opts.Throttle != nil && opts.Throttle.HeldSince(windowStart)
+					if !held && float64(n-last)/opts.SlowWindow.Seconds() < float64(minSpeed) {
+						tooSlow.Store(true)
+						cancel()
+						return
+					}
+					last, windowStart = n, now
+				}
+			}
+		}()
+	}
+
+	var sum string
+	err := errNoRanges
+	if candidate.URL != "" && opts.Connections > 1 {
+		err = fetchRanges(ctx, candidate.client(), candidate.URL, dest, opts.Connections, opts.MinPartSize, resume, wrap, opts.Progress)
+		if err == nil && opts.SHA256 != "" {
+			sum, err = fileSHA256(dest)
+		}
+	}
+	if errors.Is(err, errNoRanges) {
+		// A stream starts the part file over.
+		*resume = partial{}
+		sum, err = fetchStream(ctx, candidate, dest, opts.Progress, wrap)
+	}
+	if tooSlow.Load() {
+		return received.Load(), ErrTooSlow
+	}
+	if err != nil {
+		return received.Load(), err
+	}
+	if opts.SHA256 != "" && sum != opts.SHA256 {
+		os.Remove(dest)
+		return received.Load(), fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, opts.SHA256, sum)
+	}
+	return received.Load(), nil
+}
+
+// fetchStream downloads candidate over a single stream and returns the
+// SHA-256 of what it saved.
+func fetchStream(ctx context.Context, candidate Candidate, dest string, progress Progress, wrap func(io.Reader) io.Reader) (string, error) {
+	var (
+		body  io.ReadCloser
+		total int64
+		err   error
+	)
+	if candidate.Open != nil {
+		body, total, err = candidate.Open(ctx)
+	} else {
+		body, total, err = Open(ctx, candidate.client(), candidate.URL)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	if err := Save(io.TeeReader(wrap(body), hash), total, dest, progress); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n.Add(int64(n))
+	return n, err
+}
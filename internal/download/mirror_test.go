@@ -0,0 +1,218 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// errReset stands for a connection dropped in the middle of a stream.
+var errReset = errors.New("connection reset")
+
+// streamCandidate streams content through Open, failing with errReset after
+// cut bytes when cut is not negative.
+func streamCandidate(key string, content []byte, cut int) Candidate {
+	return Candidate{
+		Key: key,
+		Open: func(ctx context.Context) (io.ReadCloser, int64, error) {
+			var r io.Reader = bytes.NewReader(content)
+			if cut >= 0 {
+				r = io.MultiReader(io.LimitReader(r, int64(cut)), &errReader{errReset})
+			}
+			return io.NopCloser(r), int64(len(content)), nil
+		},
+	}
+}
+
+type errReader struct{ err error }
+
+func (r *errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestFailover(t *testing.T) {
+	content := testContent(64 << 10)
+	sum := sha256.Sum256(content)
+	good := func(w http.ResponseWriter, r *http.Request) { serveContent(w, r, content) }
+
+	tests := []struct {
+		name   string
+		first  http.HandlerFunc
+		stream bool
+		// wantSecond are the ranges asked of the second mirror, or nil
+		// when it is not expected to be used.
+		wantSecond []string
+	}{
+		{
+			name: "first mirror down",
+			first: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			wantSecond: quarters,
+		},
+		{
+			name: "first mirror fails ranges",
+			first: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				serveContent(w, r, content)
+			},
+			wantSecond: quarters,
+		},
+		{
+			name: "first mirror resets partway",
+			first: func(w http.ResponseWriter, r *http.Request) {
+				// Every range ends after 1000 bytes, and asking again
+				// fails.
+				if !slices.Contains(quarters, r.Header.Get("Range")) && r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				serveContent(&cutWriter{ResponseWriter: w, n: 1000}, r, content)
+			},
+			wantSecond: []string{"bytes=1000-16383", "bytes=17384-32767", "bytes=33768-49151", "bytes=50152-65535"},
+		},
+		{
+			name: "first mirror serves another file",
+			first: func(w http.ResponseWriter, r *http.Request) {
+				serveContent(w, r, bytes.Repeat([]byte{1}, len(content)))
+			},
+			wantSecond: quarters,
+		},
+		{
+			name: "first mirror ignores ranges",
+			first: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					serveContent(w, r, content)
+					return
+				}
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				w.Write(content)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := httptest.NewServer(tt.first)
+			defer first.Close()
+			var log requestLog
+			second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				log.add(r)
+				good(w, r)
+			}))
+			defer second.Close()
+
+			stats := NewStats(nil)
+			dest := filepath.Join(t.TempDir(), "game.zip")
+			err := Failover(context.Background(), []Candidate{
+				{Key: "first", URL: first.URL, Client: first.Client()},
+				{Key: "second", URL: second.URL, Client: second.Client()},
+			}, dest, FailoverOptions{
+				SHA256:      hex.EncodeToString(sum[:]),
+				Stats:       stats,
+				Connections: 4,
+				MinPartSize: 1024,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertFile(t, dest, content)
+
+			got := log.get()
+			slices.Sort(got)
+			if !slices.Equal(got, tt.wantSecond) {
+				t.Errorf("second mirror was asked for %q, want %q", got, tt.wantSecond)
+			}
+			mirrors := stats.Snapshot()
+			if tt.wantSecond != nil && (mirrors["first"].Failures != 1 || mirrors["second"].Successes != 1) {
+				t.Errorf("stats = %+v, want a failure for first and a success for second", mirrors)
+			}
+		})
+	}
+}
+
+func TestFailoverStream(t *testing.T) {
+	content := testContent(64 << 10)
+	sum := sha256.Sum256(content)
+	stats := NewStats(nil)
+	dest := filepath.Join(t.TempDir(), "game.zip")
+	err := Failover(context.Background(), []Candidate{
+		streamCandidate("first", content, 1000),
+		streamCandidate("second", content, -1),
+	}, dest, FailoverOptions{SHA256: hex.EncodeToString(sum[:]), Stats: stats})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, dest, content)
+
+	// The mirror that worked is tried first from now on.
+	order := stats.Order([]Candidate{{Key: "first"}, {Key: "second"}})
+	if order[0].Key != "second" {
+		t.Errorf("Order() puts %s first, want second", order[0].Key)
+	}
+}
+
+func TestFailoverAllFail(t *testing.T) {
+	content := testContent(64 << 10)
+	sum := sha256.Sum256(content)
+	partway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !slices.Contains(quarters, r.Header.Get("Range")) && r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		serveContent(&cutWriter{ResponseWriter: w, n: 1000}, r, content)
+	}))
+	defer partway.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	dest := filepath.Join(t.TempDir(), "game.zip")
+	err := Failover(context.Background(), []Candidate{
+		{Key: "partway", URL: partway.URL, Client: partway.Client()},
+		streamCandidate("wrong", bytes.Repeat([]byte{1}, len(content)), -1),
+		{Key: "down", URL: down.URL, Client: down.Client()},
+	}, dest, FailoverOptions{
+		SHA256:      hex.EncodeToString(sum[:]),
+		Connections: 4,
+		MinPartSize: 1024,
+	})
+	if err == nil {
+		t.Fatal("Failover() succeeded without a working mirror")
+	}
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Failover() error = %v, want it to wrap %v", err, ErrChecksumMismatch)
+	}
+	assertNothing(t, dest)
+}
@@ -0,0 +1,241 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"p86l/internal/version"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// errNoRanges means a file cannot be fetched in ranges and has to be
+// streamed instead.
+var errNoRanges = errors.New("byte ranges not supported")
+
+// probeRanges asks the server whether url can be fetched in byte ranges. It
+// returns the URL after redirects, so signed redirect targets are only
+// resolved once, and the size of the file.
+func probeRanges(ctx context.Context, client *http.Client, url string) (string, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength <= 0 {
+		return "", 0, errNoRanges
+	}
+	if !strings.Contains(strings.ToLower(resp.Header.Get("Accept-Ranges")), "bytes") {
+		return "", 0, errNoRanges
+	}
+	return resp.Request.URL.String(), resp.ContentLength, nil
+}
+
+// rangeRetries is how many times a range that ended early is asked for
+// again from where it stopped.
+const rangeRetries = 3
+
+// byteRange is the span of a file from start to end, inclusive.
+type byteRange struct {
+	start, end int64
+}
+
+// partial is what a failed ranged download left in its ".part" file: the
+// size of the file and the ranges still missing from it.
+type partial struct {
+	size    int64
+	missing []byteRange
+}
+
+// fetchRanges downloads url into dest over up to connections concurrent
+// range requests written straight into a ".part" file. Every body is passed
+// through wrap. It returns errNoRanges when the server does not advertise
+// ranges, answers a range request with the whole file, or the file is too
+// small to split, leaving nothing behind.
+//
+// When resume is not nil, a failed download keeps its part file and records
+// the missing ranges in resume, and a later call for a file of the same
+// size only fetches those.
+func fetchRanges(ctx context.Context, client *http.Client, url, dest string, connections int, minPartSize int64, resume *partial, wrap func(io.Reader) io.Reader, progress Progress) error {
+	url, size, err := probeRanges(ctx, client, url)
+	if err != nil {
+		return err
+	}
+	part := dest + ".part"
+	var (
+		f       *os.File
+		missing []byteRange
+	)
+	if resume != nil && resume.size == size && len(resume.missing) > 0 {
+		if f, err = os.OpenFile(part, os.O_WRONLY, 0); err == nil {
+			missing = resume.missing
+		}
+	}
+	if f == nil {
+		parts := int64(connections)
+		if minPartSize > 0 {
+			parts = min(parts, size/minPartSize)
+		}
+		if parts < 2 {
+			return errNoRanges
+		}
+		if f, err = createPart(part, size); err != nil {
+			return err
+		}
+		chunk := size / parts
+		for i := range parts {
+			r := byteRange{start: i * chunk, end: (i+1)*chunk - 1}
+			if i == parts-1 {
+				r.end = size - 1
+			}
+			missing = append(missing, r)
+		}
+	}
+
+	var done atomic.Int64
+	done.Store(size)
+	for _, r := range missing {
+		done.Add(r.start - r.end - 1)
+	}
+	read := func(body io.Reader) io.Reader {
+		return &progressReader{r: wrap(body), done: &done, total: size, progress: progress}
+	}
+	left := make([]byteRange, len(missing))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, r := range missing {
+		g.Go(func() error {
+			var err error
+			left[i], err = fetchRange(gctx, client, url, f, r, read)
+			return err
+		})
+	}
+	err = g.Wait()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	} else if resume != nil && !errors.Is(err, errNoRanges) {
+		// What was received stays for the next mirror.
+		resume.size = size
+		resume.missing = slices.DeleteFunc(left, func(r byteRange) bool { return r.start > r.end })
+		return err
+	}
+	if resume != nil {
+		*resume = partial{}
+	}
+	if err != nil {
+		os.Remove(part)
+		return err
+	}
+	return os.Rename(part, dest)
+}
+
+// createPart creates the part file of size bytes that ranges are written
+// into.
+func createPart(part string, size int64) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(part), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(part)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		os.Remove(part)
+		return nil, err
+	}
+	return f, nil
+}
+
+// fetchRange writes r of url into f at its offset and returns what is left
+// of r. A response that ends early is followed by a request for the rest of
+// the range, as long as each attempt makes progress.
+func fetchRange(ctx context.Context, client *http.Client, url string, f *os.File, r byteRange, read func(io.Reader) io.Reader) (byteRange, error) {
+	for attempt := 0; ; attempt++ {
+		body, err := openRange(ctx, client, url, r.start, r.end)
+		if err != nil {
+			return r, err
+		}
+		n, err := io.Copy(io.NewOffsetWriter(f, r.start), read(io.LimitReader(body, r.end-r.start+1)))
+		body.Close()
+		r.start += n
+		if err == nil && r.start <= r.end {
+			err = fmt.Errorf("range %d-%d: %w", r.start, r.end, io.ErrUnexpectedEOF)
+		}
+		if err == nil || n == 0 || attempt == rangeRetries || ctx.Err() != nil {
+			return r, err
+		}
+	}
+}
+
+// openRange requests bytes start to end, inclusive, of url. A server that
+// answers with the whole file ignores ranges, which is errNoRanges.
+func openRange(ctx context.Context, client *http.Client, url string, start, end int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return nil, fmt.Errorf("download %s range %d-%d: %w", url, start, end, errNoRanges)
+		}
+		return nil, fmt.Errorf("download %s range %d-%d: %s", url, start, end, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// progressReader reports the bytes read by all ranges of one file.
+type progressReader struct {
+	r        io.Reader
+	done     *atomic.Int64
+	total    int64
+	progress Progress
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	done := p.done.Add(int64(n))
+	if p.progress != nil && n > 0 {
+		p.progress(done, p.total)
+	}
+	return n, err
+}
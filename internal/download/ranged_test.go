@@ -0,0 +1,286 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testContent returns size bytes that differ from one offset to the next,
+// so misplaced ranges show.
+func testContent(size int) []byte {
+	b := make([]byte, size)
+	for i := range b {
+		b[i] = byte(i * 7 % 251)
+	}
+	return b
+}
+
+// serveContent answers HEAD and range requests for content.
+func serveContent(w http.ResponseWriter, r *http.Request, content []byte) {
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+}
+
+// cutWriter sends n bytes of the body at most. As the length was announced,
+// net/http drops the connection and the client sees the body end early.
+type cutWriter struct {
+	http.ResponseWriter
+	n int
+}
+
+func (w *cutWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b[:min(len(b), w.n)])
+	w.n -= n
+	return n, err
+}
+
+// requestLog records the Range header of every GET request.
+type requestLog struct {
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (l *requestLog) add(r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r.Method == http.MethodGet {
+		l.ranges = append(l.ranges, r.Header.Get("Range"))
+	}
+}
+
+func (l *requestLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.ranges)
+}
+
+func assertFile(t *testing.T, dest string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the served file", filepath.Base(dest))
+	}
+	if _, err := os.Stat(dest + ".part"); !os.IsNotExist(err) {
+		t.Errorf("part file left behind: %v", err)
+	}
+}
+
+func assertNothing(t *testing.T, dest string) {
+	t.Helper()
+	for _, path := range []string{dest, dest + ".part"} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s left behind: %v", filepath.Base(path), err)
+		}
+	}
+}
+
+func identity(r io.Reader) io.Reader { return r }
+
+func TestProbeRanges(t *testing.T) {
+	content := testContent(4096)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		serveContent(w, r, content)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/file", http.StatusFound)
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Write(content)
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	url, size, err := probeRanges(context.Background(), server.Client(), server.URL+"/redirect")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != server.URL+"/file" || size != int64(len(content)) {
+		t.Errorf("probeRanges() = %s, %d, want %s, %d", url, size, server.URL+"/file", len(content))
+	}
+	for _, path := range []string{"/plain", "/missing"} {
+		if _, _, err := probeRanges(context.Background(), server.Client(), server.URL+path); !errors.Is(err, errNoRanges) {
+			t.Errorf("probeRanges(%s) error = %v, want %v", path, err, errNoRanges)
+		}
+	}
+}
+
+func TestFetchRanges(t *testing.T) {
+	content := testContent(64 << 10)
+	var log requestLog
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.add(r)
+		serveContent(w, r, content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "game.zip")
+	// Ranges report progress concurrently.
+	var (
+		mu   sync.Mutex
+		last int64
+	)
+	progress := func(done, total int64) {
+		if total != int64(len(content)) {
+			t.Errorf("progress total = %d, want %d", total, len(content))
+		}
+		mu.Lock()
+		last = max(last, done)
+		mu.Unlock()
+	}
+	if err := fetchRanges(context.Background(), server.Client(), server.URL, dest, 4, 1024, nil, identity, progress); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, dest, content)
+	if last != int64(len(content)) {
+		t.Errorf("progress reached %d, want %d", last, len(content))
+	}
+	got := log.get()
+	slices.Sort(got)
+	if !slices.Equal(got, quarters) {
+		t.Errorf("requested %q, want %q", got, quarters)
+	}
+}
+
+func TestFetchRangesFallback(t *testing.T) {
+	content := testContent(64 << 10)
+	tests := []struct {
+		name        string
+		minPartSize int64
+		handler     http.HandlerFunc
+	}{
+		{
+			name: "no accept-ranges",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				if r.Method == http.MethodGet {
+					w.Write(content)
+				}
+			},
+		},
+		{
+			name: "whole file for a range",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					serveContent(w, r, content)
+					return
+				}
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				w.Write(content)
+			},
+		},
+		{
+			name:        "too small to split",
+			minPartSize: 48 << 10,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				serveContent(w, r, content)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			dest := filepath.Join(t.TempDir(), "game.zip")
+			err := fetchRanges(context.Background(), server.Client(), server.URL, dest, 4, tt.minPartSize, nil, identity, nil)
+			if !errors.Is(err, errNoRanges) {
+				t.Fatalf("fetchRanges() error = %v, want %v", err, errNoRanges)
+			}
+			assertNothing(t, dest)
+		})
+	}
+}
+
+// quarters are the ranges a 64 KiB file is split into over 4 connections.
+var quarters = []string{"bytes=0-16383", "bytes=16384-32767", "bytes=32768-49151", "bytes=49152-65535"}
+
+func TestFetchRangesResume(t *testing.T) {
+	content := testContent(64 << 10)
+	var log requestLog
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.add(r)
+		// The first request for every range ends after 1000 bytes.
+		if slices.Contains(quarters, r.Header.Get("Range")) {
+			w = &cutWriter{ResponseWriter: w, n: 1000}
+		}
+		serveContent(w, r, content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "game.zip")
+	if err := fetchRanges(context.Background(), server.Client(), server.URL, dest, 4, 1024, nil, identity, nil); err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, dest, content)
+	got := log.get()
+	slices.Sort(got)
+	want := []string{
+		"bytes=0-16383", "bytes=1000-16383",
+		"bytes=16384-32767", "bytes=17384-32767",
+		"bytes=32768-49151", "bytes=33768-49151",
+		"bytes=49152-65535", "bytes=50152-65535",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("requested %q, want %q", got, want)
+	}
+}
+
+func TestFetchRangesFailure(t *testing.T) {
+	content := testContent(64 << 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Range") {
+		case "bytes=16384-32767":
+			w = &cutWriter{ResponseWriter: w, n: 1000}
+		case "bytes=17384-32767":
+			// The rest of the range fails outright, which ends the
+			// download instead of asking again.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		serveContent(w, r, content)
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "game.zip")
+	if err := fetchRanges(context.Background(), server.Client(), server.URL, dest, 4, 1024, nil, identity, nil); err == nil {
+		t.Fatal("fetchRanges() succeeded with a failing range")
+	}
+	assertNothing(t, dest)
+}
@@ -0,0 +1,98 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule is a daily window, in local time, in which downloads run. The
+// window may wrap past midnight, such as 22:00 to 06:00. A schedule whose
+// start equals its end, including the zero Schedule, always allows
+// downloads.
+type Schedule struct {
+	// Start and End are offsets from midnight.
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseSchedule reads a window given as "15:04" times. Two empty times
+// give the always-on Schedule.
+func ParseSchedule(start, end string) (Schedule, error) {
+	if start == "" && end == "" {
+		return Schedule{}, nil
+	}
+	s, err := parseClock(start)
+	if err != nil {
+		return Schedule{}, err
+	}
+	e, err := parseClock(end)
+	if err != nil {
+		return Schedule{}, err
+	}
+	return Schedule{Start: s, End: e}, nil
+}
+
+func parseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", clock)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (s Schedule) Always() bool {
+	return s.Start == s.End
+}
+
+// sinceMidnight returns the wall clock time of t, which differs from the
+// time elapsed since midnight on days the clocks change.
+func sinceMidnight(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+}
+
+func (s Schedule) Allowed(now time.Time) bool {
+	if s.Always() {
+		return true
+	}
+	t := sinceMidnight(now)
+	if s.Start < s.End {
+		return t >= s.Start && t < s.End
+	}
+	return t >= s.Start || t < s.End
+}
+
+// Next returns when the window opens next, or now when it is open.
+func (s Schedule) Next(now time.Time) time.Time {
+	if s.Allowed(now) {
+		return now
+	}
+	// time.Date normalizes the offset as wall clock time.
+	y, m, d := now.Date()
+	next := time.Date(y, m, d, 0, 0, 0, int(s.Start), now.Location())
+	if !next.After(now) {
+		next = time.Date(y, m, d+1, 0, 0, 0, int(s.Start), now.Location())
+	}
+	return next
+}
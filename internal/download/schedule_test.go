@@ -0,0 +1,115 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func clock(t *testing.T, s string) time.Time {
+	t.Helper()
+	now, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return now
+}
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		start, end string
+		want       Schedule
+		wantErr    bool
+	}{
+		{"", "", Schedule{}, false},
+		{"22:00", "06:00", Schedule{Start: 22 * time.Hour, End: 6 * time.Hour}, false},
+		{"00:30", "23:59", Schedule{Start: 30 * time.Minute, End: 23*time.Hour + 59*time.Minute}, false},
+		{"22:00", "", Schedule{}, true},
+		{"24:00", "06:00", Schedule{}, true},
+		{"10pm", "6am", Schedule{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSchedule(tt.start, tt.end)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSchedule(%q, %q) = %v, %v, want %v, error %v", tt.start, tt.end, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestScheduleAllowed(t *testing.T) {
+	day := Schedule{Start: 9 * time.Hour, End: 17 * time.Hour}
+	night := Schedule{Start: 22 * time.Hour, End: 6 * time.Hour}
+	tests := []struct {
+		schedule Schedule
+		now      string
+		want     bool
+		wantNext string
+	}{
+		{Schedule{}, "2026-10-19 03:00", true, "2026-10-19 03:00"},
+		{Schedule{Start: 5 * time.Hour, End: 5 * time.Hour}, "2026-10-19 12:00", true, "2026-10-19 12:00"},
+		{day, "2026-10-19 08:59", false, "2026-10-19 09:00"},
+		{day, "2026-10-19 09:00", true, "2026-10-19 09:00"},
+		{day, "2026-10-19 16:59", true, "2026-10-19 16:59"},
+		{day, "2026-10-19 17:00", false, "2026-10-20 09:00"},
+		{night, "2026-10-19 21:59", false, "2026-10-19 22:00"},
+		{night, "2026-10-19 22:00", true, "2026-10-19 22:00"},
+		{night, "2026-10-19 23:59", true, "2026-10-19 23:59"},
+		{night, "2026-10-20 00:00", true, "2026-10-20 00:00"},
+		{night, "2026-10-20 05:59", true, "2026-10-20 05:59"},
+		{night, "2026-10-20 06:00", false, "2026-10-20 22:00"},
+		{night, "2026-12-31 23:30", true, "2026-12-31 23:30"},
+		{night, "2026-12-31 12:00", false, "2026-12-31 22:00"},
+	}
+	for _, tt := range tests {
+		now := clock(t, tt.now)
+		if got := tt.schedule.Allowed(now); got != tt.want {
+			t.Errorf("%v.Allowed(%s) = %v, want %v", tt.schedule, tt.now, got, tt.want)
+		}
+		if got, want := tt.schedule.Next(now), clock(t, tt.wantNext); !got.Equal(want) {
+			t.Errorf("%v.Next(%s) = %s, want %s", tt.schedule, tt.now, got, want)
+		}
+	}
+}
+
+// The window follows the wall clock on days the clocks change.
+func TestScheduleClockChange(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Clocks went from 02:00 to 03:00 on 2026-03-29.
+	window := Schedule{Start: 4 * time.Hour, End: 6 * time.Hour}
+	if at := time.Date(2026, 3, 29, 4, 30, 0, 0, berlin); !window.Allowed(at) {
+		t.Errorf("%v.Allowed(%s) = false", window, at)
+	}
+	at := time.Date(2026, 3, 29, 1, 0, 0, 0, berlin)
+	if got, want := window.Next(at), time.Date(2026, 3, 29, 4, 0, 0, 0, berlin); !got.Equal(want) {
+		t.Errorf("%v.Next(%s) = %s, want %s", window, at, got, want)
+	}
+	// And from 03:00 back to 02:00 on 2026-10-25.
+	at = time.Date(2026, 10, 25, 1, 0, 0, 0, berlin)
+	if got, want := window.Next(at), time.Date(2026, 10, 25, 4, 0, 0, 0, berlin); !got.Equal(want) {
+		t.Errorf("%v.Next(%s) = %s, want %s", window, at, got, want)
+	}
+}
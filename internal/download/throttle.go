@@ -0,0 +1,200 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Throttle is shared by every download. It caps their combined speed and
+// holds them while paused or outside the schedule.
+type Throttle struct {
+	mu       sync.Mutex
+	limit    int64
+	paused   bool
+	schedule Schedule
+	tokens   float64
+	last     time.Time
+	// held is the last time Wait held a reader.
+	held time.Time
+	// changed is closed and replaced whenever a setting changes, to wake
+	// up waiting readers.
+	changed chan struct{}
+	// now is the clock, replaced in tests.
+	now func() time.Time
+}
+
+func NewThrottle() *Throttle {
+	return &Throttle{changed: make(chan struct{}), now: time.Now}
+}
+
+func (t *Throttle) notify() {
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// SetLimit caps the speed in bytes per second. Zero removes the cap.
+func (t *Throttle) SetLimit(limit int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.limit = max(limit, 0)
+	t.tokens = 0
+	t.notify()
+}
+
+func (t *Throttle) SetPaused(paused bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.paused = paused
+	t.notify()
+}
+
+func (t *Throttle) SetSchedule(schedule Schedule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.schedule = schedule
+	t.notify()
+}
+
+func (t *Throttle) Limit() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit
+}
+
+func (t *Throttle) Paused() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.paused
+}
+
+func (t *Throttle) Schedule() Schedule {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.schedule
+}
+
+// HeldSince reports whether a download was held since start, or is held
+// now.
+func (t *Throttle) HeldSince(start time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.paused || !t.schedule.Allowed(t.now()) || t.held.After(start)
+}
+
+// Wait blocks while downloads are paused or outside the schedule.
+func (t *Throttle) Wait(ctx context.Context) error {
+	for {
+		now := t.now()
+		t.mu.Lock()
+		paused, schedule, changed := t.paused, t.schedule, t.changed
+		if paused || !schedule.Allowed(now) {
+			t.held = now
+		}
+		t.mu.Unlock()
+		if !paused && schedule.Allowed(now) {
+			return nil
+		}
+
+		// A pause only ends through a setting change, a closed window also
+		// when it opens again.
+		wait := time.Duration(math.MaxInt64)
+		if !paused {
+			wait = schedule.Next(now).Sub(now)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-changed:
+		case <-timer.C:
+		}
+		timer.Stop()
+
+		t.mu.Lock()
+		t.held = t.now()
+		t.mu.Unlock()
+	}
+}
+
+// take spends n bytes of the speed cap, sleeping when they are not
+// available yet. The cap allows bursts of up to one second.
+func (t *Throttle) take(ctx context.Context, n int) error {
+	t.mu.Lock()
+	if t.limit == 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	now := t.now()
+	if !t.last.IsZero() {
+		t.tokens = min(t.tokens+now.Sub(t.last).Seconds()*float64(t.limit), float64(t.limit))
+	}
+	t.last = now
+	t.tokens -= float64(n)
+	wait := time.Duration(-t.tokens / float64(t.limit) * float64(time.Second))
+	t.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Reader wraps r so it follows the throttle.
+func (t *Throttle) Reader(ctx context.Context, r io.Reader) io.Reader {
+	return &throttledReader{ctx: ctx, r: r, t: t}
+}
+
+type throttledReader struct {
+	ctx context.Context
+	r   io.Reader
+	t   *Throttle
+}
+
+func (r *throttledReader) Read(b []byte) (int, error) {
+	if err := r.t.Wait(r.ctx); err != nil {
+		return 0, err
+	}
+	// Small reads keep the speed even under a low cap.
+	if limit := r.t.Limit(); limit > 0 && int64(len(b)) > max(limit/4, 1024) {
+		b = b[:max(limit/4, 1024)]
+	}
+	n, err := r.r.Read(b)
+	if n > 0 {
+		if err := r.t.take(r.ctx, n); err != nil {
+			return n, err
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,181 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestThrottle(now time.Time) (*Throttle, *fakeClock) {
+	c := &fakeClock{now: now}
+	t := NewThrottle()
+	t.now = c.Now
+	return t, c
+}
+
+// blocked reports whether Wait holds a reader for a moment.
+func blocked(t *Throttle) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	return errors.Is(t.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestThrottleWait(t *testing.T) {
+	night := Schedule{Start: 22 * time.Hour, End: 6 * time.Hour}
+	tests := []struct {
+		name     string
+		now      string
+		paused   bool
+		schedule Schedule
+		want     bool
+	}{
+		{"always", "2026-10-19 12:00", false, Schedule{}, false},
+		{"paused", "2026-10-19 12:00", true, Schedule{}, true},
+		{"before the window", "2026-10-19 21:59", false, night, true},
+		{"in the window", "2026-10-19 23:00", false, night, false},
+		{"past midnight", "2026-10-20 00:30", false, night, false},
+		{"after the window", "2026-10-20 06:00", false, night, true},
+		{"paused in the window", "2026-10-19 23:00", true, night, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th, c := newTestThrottle(clock(t, tt.now))
+			th.SetPaused(tt.paused)
+			th.SetSchedule(tt.schedule)
+			start := c.Now().Add(-time.Minute)
+			if got := blocked(th); got != tt.want {
+				t.Errorf("Wait() blocked = %v, want %v", got, tt.want)
+			}
+			if got := th.HeldSince(start); got != tt.want {
+				t.Errorf("HeldSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThrottleWaitWakes(t *testing.T) {
+	th, c := newTestThrottle(clock(t, "2026-10-19 21:00"))
+	th.SetSchedule(Schedule{Start: 22 * time.Hour, End: 6 * time.Hour})
+	th.SetPaused(true)
+
+	done := make(chan error)
+	go func() { done <- th.Wait(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Wait() = %v while paused", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Resuming outside the window keeps holding the reader.
+	th.SetPaused(false)
+	select {
+	case err := <-done:
+		t.Fatalf("Wait() = %v outside the window", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Once the window opens, a setting change lets it through.
+	c.Set(clock(t, "2026-10-19 22:00"))
+	th.SetLimit(0)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Wait() = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait() still holds the reader in the window")
+	}
+
+	// The download was held in the last minute, but not since.
+	if !th.HeldSince(c.Now().Add(-time.Minute)) {
+		t.Error("HeldSince() = false after a hold")
+	}
+	c.Advance(time.Minute)
+	if th.HeldSince(c.Now()) {
+		t.Error("HeldSince() = true with nothing held")
+	}
+}
+
+func TestThrottleTake(t *testing.T) {
+	th, c := newTestThrottle(clock(t, "2026-10-19 12:00"))
+	th.SetLimit(1000)
+	// A cancelled context makes take fail when it would have to sleep.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	steps := []struct {
+		advance time.Duration
+		n       int
+		wait    bool
+	}{
+		// The cap starts empty.
+		{0, 1, true},
+		{2 * time.Second, 999, false},
+		// Bursts are capped at one second, so two idle seconds do not
+		// allow 2000 bytes.
+		{2 * time.Second, 1000, false},
+		{0, 1, true},
+		{500 * time.Millisecond, 400, false},
+		{0, 200, true},
+	}
+	for i, step := range steps {
+		c.Advance(step.advance)
+		err := th.take(ctx, step.n)
+		if wait := err != nil; wait != step.wait {
+			t.Errorf("step %d: take(%d) waited = %v, want %v", i, step.n, wait, step.wait)
+		}
+	}
+
+	// Without a cap nothing waits.
+	th.SetLimit(0)
+	if err := th.take(ctx, 1<<30); err != nil {
+		t.Errorf("take() without a cap = %v", err)
+	}
+}
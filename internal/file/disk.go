@@ -0,0 +1,171 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"p86l/internal/debug"
+	"path/filepath"
+	"slices"
+)
+
+type Usage struct {
+	Games     int64
+	Downloads int64
+	Cache     int64
+	Logs      int64
+}
+
+func (u *Usage) Total() int64 {
+	return u.Games + u.Downloads + u.Cache + u.Logs
+}
+
+// DirSize returns the size of every regular file below path. A missing
+// directory is reported as empty.
+func DirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func (afs *AppFS) Usage(appDebug *debug.Debug) (*Usage, *debug.Error) {
+	usage := &Usage{}
+	for _, entry := range []struct {
+		dir  func(*debug.Debug) (string, *debug.Error)
+		size *int64
+	}{
+		{afs.GamesDir, &usage.Games},
+		{afs.DownloadsDir, &usage.Downloads},
+		{afs.CacheDir, &usage.Cache},
+		{afs.LogDir, &usage.Logs},
+	} {
+		dir, err := entry.dir(appDebug)
+		if err.Err != nil {
+			return nil, err
+		}
+		size, _err := DirSize(dir)
+		if _err != nil {
+			return nil, appDebug.New(_err, debug.FSError, debug.ErrDiskUsage)
+		}
+		*entry.size = size
+	}
+	return usage, appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// FreeSpace returns the bytes available to the launcher on the filesystem
+// holding path. Directories that do not exist yet are resolved to their
+// closest existing parent.
+func (afs *AppFS) FreeSpace(appDebug *debug.Debug, path string) (uint64, *debug.Error) {
+	path, _err := existingParent(path)
+	if _err != nil {
+		return 0, appDebug.New(_err, debug.FSError, debug.ErrFreeSpace)
+	}
+
+	free, _err := freeSpace(path)
+	if _err != nil {
+		return 0, appDebug.New(_err, debug.FSError, debug.ErrFreeSpace)
+	}
+	return free, appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// existingParent returns the absolute path, or its closest parent that
+// exists.
+func existingParent(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path, nil
+		}
+		path = parent
+	}
+}
+
+// CheckSpace checks the filesystems holding the paths of required have
+// room for the bytes needed there. Paths on the same filesystem add up.
+func (afs *AppFS) CheckSpace(appDebug *debug.Debug, required map[string]uint64) *debug.Error {
+	paths := slices.Sorted(maps.Keys(required))
+	volumes := map[string]string{}
+	needed := map[string]uint64{}
+	for _, path := range paths {
+		existing, _err := existingParent(path)
+		if _err != nil {
+			return appDebug.New(_err, debug.FSError, debug.ErrFreeSpace)
+		}
+		id, _err := volume(existing)
+		if _err != nil {
+			return appDebug.New(_err, debug.FSError, debug.ErrFreeSpace)
+		}
+		if _, ok := volumes[id]; !ok {
+			volumes[id] = path
+		}
+		needed[id] += required[path]
+	}
+	for id, path := range volumes {
+		free, err := afs.FreeSpace(appDebug, path)
+		if err.Err != nil {
+			return err
+		}
+		if free < needed[id] {
+			return appDebug.New(fmt.Errorf("not enough space in %s: need %s, have %s", path, FormatSize(int64(needed[id])), FormatSize(int64(free))), debug.InstallError, debug.ErrInsufficientSpace)
+		}
+	}
+	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
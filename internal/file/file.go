@@ -80,44 +80,158 @@ func (afs *AppFS) LauncherDir(appDebug *debug.Debug) (string, *debug.Error) {
 }
 
 func (afs *AppFS) LogDir(appDebug *debug.Debug) (string, *debug.Error) {
-	if afs.IsDir() {
-		_, err := afs.LauncherDir(appDebug)
+	return afs.launcherSubDir(appDebug, configs.Logs)
+}
+
+func (afs *AppFS) GamesDir(appDebug *debug.Debug) (string, *debug.Error) {
+	return afs.launcherSubDir(appDebug, configs.Games)
+}
+
+func (afs *AppFS) DownloadsDir(appDebug *debug.Debug) (string, *debug.Error) {
+	return afs.launcherSubDir(appDebug, configs.Downloads)
+}
+
+func (afs *AppFS) PrefixesDir(appDebug *debug.Debug) (string, *debug.Error) {
+	return afs.launcherSubDir(appDebug, configs.Prefixes)
+}
+
+func (afs *AppFS) CacheDir(appDebug *debug.Debug) (string, *debug.Error) {
+	return afs.launcherSubDir(appDebug, configs.Cache)
+}
+
+func (afs *AppFS) DataDir(appDebug *debug.Debug) (string, *debug.Error) {
+	return afs.launcherSubDir(appDebug, configs.Data)
+}
+
+type Category struct {
+	Name string
+	Dir  string
+	// Keep holds entries of Dir that belong to other categories.
+	Keep []string
+}
+
+// Categories lists the folders below LauncherDir that the user can clear
+// one by one, followed by an "Other" category for everything else in
+// LauncherDir so that clearing all of them empties it.
+func (afs *AppFS) Categories(appDebug *debug.Debug) ([]Category, *debug.Error) {
+	launcherDir, err := afs.LauncherDir(appDebug)
+	if err.Err != nil {
+		return nil, err
+	}
+
+	var categories []Category
+	var keep []string
+	for _, entry := range []struct {
+		name string
+		dir  func(*debug.Debug) (string, *debug.Error)
+	}{
+		{"Games", afs.GamesDir},
+		{"Downloads", afs.DownloadsDir},
+		{"Prefixes", afs.PrefixesDir},
+		{"Cache", afs.CacheDir},
+		{"Data", afs.DataDir},
+		{"Logs", afs.LogDir},
+	} {
+		dir, err := entry.dir(appDebug)
 		if err.Err != nil {
-			return "", err
+			return nil, err
 		}
+		categories = append(categories, Category{Name: entry.name, Dir: dir})
+		keep = append(keep, dir)
+	}
+	categories = append(categories, Category{Name: "Other", Dir: launcherDir, Keep: keep})
+	return categories, appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
 
-		if runtime.GOOS == "windows" {
-			return afs.clean() + configs.AppName + "\\logs", appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+func (afs *AppFS) launcherSubDir(appDebug *debug.Debug, name string) (string, *debug.Error) {
+	dir, err := afs.LauncherDir(appDebug)
+	if err.Err != nil {
+		return "", appDebug.New(fmt.Errorf("%s dir not found", name), debug.FSError, debug.ErrDirNotFound)
+	}
+	return filepath.Join(dir, name), appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+type ClearOptions struct {
+	// Skip holds paths that are kept in place, such as the log file that
+	// is currently open.
+	Skip []string
+	// Trash moves entries to the trash instead of deleting them.
+	Trash bool
+	// DryRun only reports what would be removed.
+	DryRun bool
+}
+
+func (o *ClearOptions) skipped(path string) bool {
+	for _, skip := range o.Skip {
+		if filepath.Clean(skip) == path {
+			return true
 		}
-		return afs.clean() + configs.AppName + "/logs", appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
 	}
+	return false
+}
 
-	return "", appDebug.New(errors.New("LogDir not found"), debug.FSError, debug.ErrDirNotFound)
+func (o *ClearOptions) containsSkipped(dir string) bool {
+	for _, skip := range o.Skip {
+		if strings.HasPrefix(filepath.Clean(skip), dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
 }
 
-func (afs *AppFS) ClearFolder(folderPath string, appDebug *debug.Debug) *debug.Error {
+// ClearFolder removes the contents of folderPath and returns the number of
+// bytes freed, or that would be freed when opts.DryRun is set.
+func (afs *AppFS) ClearFolder(folderPath string, opts ClearOptions, appDebug *debug.Debug) (int64, *debug.Error) {
 	// Read all items in the directory
 	items, err := os.ReadDir(folderPath)
 	if err != nil {
-		return appDebug.New(fmt.Errorf("failed to read directory: %w", err), debug.FSError, debug.ErrFolderClear)
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+		}
+		return 0, appDebug.New(fmt.Errorf("failed to read directory: %w", err), debug.FSError, debug.ErrFolderClear)
 	}
 
-	// Iterate through each item and remove it
+	var freed int64
 	for _, item := range items {
 		itemPath := filepath.Join(folderPath, item.Name())
+		if opts.skipped(itemPath) {
+			log.Info().Str("Path", itemPath).Msg("Skip clearing")
+			continue
+		}
 
-		// If it's a directory, remove all contents recursively
-		if item.IsDir() {
-			if err := os.RemoveAll(itemPath); err != nil {
-				return appDebug.New(fmt.Errorf("failed to remove directory %s: %w", itemPath, err), debug.FSError, debug.ErrFolderClear)
+		// Keep the directory and only clear around the skipped entries
+		if item.IsDir() && opts.containsSkipped(itemPath) {
+			size, err := afs.ClearFolder(itemPath, opts, appDebug)
+			freed += size
+			if err.Err != nil {
+				return freed, err
 			}
+			continue
+		}
+
+		var size int64
+		if item.IsDir() {
+			size, err = DirSize(itemPath)
+		} else if info, _err := item.Info(); _err == nil {
+			size = info.Size()
 		} else {
-			// If it's a file, remove it directly
-			if err := os.Remove(itemPath); err != nil {
-				return appDebug.New(fmt.Errorf("failed to remove file %s: %w", itemPath, err), debug.FSError, debug.ErrFolderClear)
+			err = _err
+		}
+		if err != nil {
+			return freed, appDebug.New(fmt.Errorf("failed to stat %s: %w", itemPath, err), debug.FSError, debug.ErrFolderClear)
+		}
+
+		if !opts.DryRun {
+			if opts.Trash {
+				if err := MoveToTrash(itemPath); err != nil {
+					return freed, appDebug.New(fmt.Errorf("failed to trash %s: %w", itemPath, err), debug.FSError, debug.ErrFolderClear)
+				}
+			} else if err := os.RemoveAll(itemPath); err != nil {
+				return freed, appDebug.New(fmt.Errorf("failed to remove %s: %w", itemPath, err), debug.FSError, debug.ErrFolderClear)
 			}
 		}
+		freed += size
 	}
 
-	return appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
+	return freed, appDebug.New(nil, debug.UnknownError, debug.ErrUnknown)
 }
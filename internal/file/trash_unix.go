@@ -0,0 +1,168 @@
+//go:build !windows && !darwin
+
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package file
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func CanTrash() bool {
+	return true
+}
+
+func trashDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "Trash"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
+
+// topDir returns the top directory of the mount holding path: its last
+// parent on the filesystem dev.
+func topDir(path, dev string) (string, error) {
+	dir := path
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir, nil
+		}
+		id, err := volume(parent)
+		if err != nil {
+			return "", err
+		}
+		if id != dev {
+			return dir, nil
+		}
+		dir = parent
+	}
+}
+
+// trashFor returns the trash path can be renamed into: the home trash when
+// it is on the same filesystem, otherwise the trash at the top directory of
+// the filesystem of path, which is also returned.
+func trashFor(path string) (trash, top string, err error) {
+	home, err := trashDir()
+	if err != nil {
+		return "", "", err
+	}
+	dev, err := volume(path)
+	if err != nil {
+		return "", "", err
+	}
+	homeParent, err := existingParent(home)
+	if err != nil {
+		return "", "", err
+	}
+	if homeDev, err := volume(homeParent); err == nil && homeDev == dev {
+		return home, "", nil
+	}
+
+	if top, err = topDir(path, dev); err != nil {
+		return "", "", err
+	}
+	uid := strconv.Itoa(os.Getuid())
+	// An administrator provided $topdir/.Trash must be a sticky directory,
+	// not a link.
+	if info, err := os.Lstat(filepath.Join(top, ".Trash")); err == nil && info.IsDir() && info.Mode()&os.ModeSticky != 0 {
+		trash := filepath.Join(top, ".Trash", uid)
+		if err := os.MkdirAll(trash, 0700); err == nil {
+			return trash, top, nil
+		}
+	}
+	return filepath.Join(top, ".Trash-"+uid), top, nil
+}
+
+// MoveToTrash moves path to a trash as described by the freedesktop.org
+// Trash specification: the home trash, or the one of the filesystem of
+// path when it is on another one, so nothing is copied.
+func MoveToTrash(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	trash, top, err := trashFor(path)
+	if err != nil {
+		return err
+	}
+	filesDir := filepath.Join(trash, "files")
+	infoDir := filepath.Join(trash, "info")
+	if err := os.MkdirAll(filesDir, 0700); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(infoDir, 0700); err != nil {
+		return err
+	}
+
+	// Top directory trashes keep paths relative to their top directory.
+	infoPath := path
+	if top != "" {
+		if infoPath, err = filepath.Rel(top, path); err != nil {
+			return err
+		}
+	}
+	segments := strings.Split(filepath.ToSlash(infoPath), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	info := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n", strings.Join(segments, "/"), time.Now().Format("2006-01-02T15:04:05"))
+
+	// The info file is created exclusively to reserve the name.
+	base := filepath.Base(path)
+	for i := 1; ; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s.%d", base, i)
+		}
+		infoPath := filepath.Join(infoDir, name+".trashinfo")
+		f, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		_, err = f.WriteString(info)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err == nil {
+			err = os.Rename(path, filepath.Join(filesDir, name))
+		}
+		if err != nil {
+			os.Remove(infoPath)
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package manifest reads the launcher manifest: settings the maintainers
+// change between launcher releases. The manifest is a JSON document
+// wrapped with its ed25519 signature:
+//
+//	{"manifest": {...}, "signature": "<base64>"}
+//
+// The signature covers the manifest bytes exactly as they appear.
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"p86l/configs"
+	"slices"
+)
+
+// maxSize bounds the manifest download.
+const maxSize = 256 << 10
+
+var (
+	ErrNoKey     = errors.New("no manifest public key")
+	ErrSignature = errors.New("manifest signature does not match")
+)
+
+type Repo struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+// Banner is an announcement shown on Home. URL, when set, is opened when
+// the banner is clicked.
+type Banner struct {
+	Text string `json:"text"`
+	URL  string `json:"url,omitempty"`
+}
+
+type Manifest struct {
+	// Version increases with every published manifest. A manifest older
+	// than the one already seen is ignored, so an outdated signed copy
+	// cannot be replayed.
+	Version int `json:"version"`
+	// Repo replaces configs.RepoOwner and configs.RepoName.
+	Repo *Repo `json:"repo,omitempty"`
+	// MinLauncherVersion is the oldest launcher allowed to install games.
+	MinLauncherVersion string  `json:"min_launcher_version,omitempty"`
+	Banner             *Banner `json:"banner,omitempty"`
+	// Features turns features on or off over configs.Features.
+	Features map[string]bool `json:"features,omitempty"`
+	// Withdrawn lists the tags of broken releases, which are hidden from
+	// every channel.
+	Withdrawn []string `json:"withdrawn,omitempty"`
+	// AssetRules replaces configs.AssetRules, for release naming changes.
+	AssetRules []configs.AssetRule `json:"asset_rules,omitempty"`
+}
+
+// Replaces reports whether fetched may replace cached, the manifest seen
+// last, which may be nil. It refuses older versions.
+func Replaces(fetched, cached *Manifest) bool {
+	return cached == nil || fetched.Version >= cached.Version
+}
+
+func (m *Manifest) IsWithdrawn(tag string) bool {
+	return slices.Contains(m.Withdrawn, tag)
+}
+
+type signed struct {
+	Manifest  json.RawMessage `json:"manifest"`
+	Signature string          `json:"signature"`
+}
+
+// ParseKey decodes a base64 ed25519 public key.
+func ParseKey(key string) (ed25519.PublicKey, error) {
+	if key == "" {
+		return nil, ErrNoKey
+	}
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("manifest public key: %w", err)
+	}
+	if len(decoded) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("manifest public key is %d bytes, not %d", len(decoded), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(decoded), nil
+}
+
+// Verify checks the signature of a signed manifest and decodes it.
+func Verify(data []byte, key ed25519.PublicKey) (*Manifest, error) {
+	var s signed
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("signed manifest: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(s.Signature)
+	if err != nil || !ed25519.Verify(key, s.Manifest, signature) {
+		return nil, ErrSignature
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(s.Manifest, m); err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	return m, nil
+}
+
+// Sign wraps a manifest document with its signature.
+func Sign(manifest []byte, key ed25519.PrivateKey) ([]byte, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, manifest); err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	signature := ed25519.Sign(key, compact.Bytes())
+	// Indenting would change the signed bytes.
+	return json.Marshal(signed{
+		Manifest:  compact.Bytes(),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	})
+}
+
+// Fetch downloads and verifies the manifest at url. It also returns the
+// signed document, to be cached and verified again on the next start.
+func Fetch(ctx context.Context, client *http.Client, url string, key ed25519.PublicKey) (*Manifest, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("manifest: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) > maxSize {
+		return nil, nil, fmt.Errorf("manifest is larger than %d bytes", maxSize)
+	}
+	m, err := Verify(data, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, data, nil
+}
@@ -0,0 +1,173 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const document = `{
+	"version": 3,
+	"repo": {"owner": "owner", "name": "game"},
+	"banner": {"text": "Maintenance tonight"},
+	"features": {"news": false},
+	"withdrawn": ["v1.0.1"]
+}`
+
+func newKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return public, private
+}
+
+func TestSignVerify(t *testing.T) {
+	public, private := newKey(t)
+	signed, err := Sign([]byte(document), private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Verify(signed, public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != 3 || m.Repo == nil || m.Repo.Name != "game" || m.Banner.Text != "Maintenance tonight" {
+		t.Errorf("Verify() = %+v", m)
+	}
+	if on, ok := m.Features["news"]; !ok || on {
+		t.Errorf("Features = %v, want news off", m.Features)
+	}
+	if !m.IsWithdrawn("v1.0.1") || m.IsWithdrawn("v1.0.0") {
+		t.Errorf("Withdrawn = %v", m.Withdrawn)
+	}
+
+	otherPublic, _ := newKey(t)
+	if _, err := Verify(signed, otherPublic); !errors.Is(err, ErrSignature) {
+		t.Errorf("Verify() with another key error = %v, want %v", err, ErrSignature)
+	}
+
+	tampered := bytes.Replace(signed, []byte(`"version":3`), []byte(`"version":4`), 1)
+	if bytes.Equal(tampered, signed) {
+		t.Fatal("test manifest not tampered")
+	}
+	if _, err := Verify(tampered, public); !errors.Is(err, ErrSignature) {
+		t.Errorf("Verify() of a changed manifest error = %v, want %v", err, ErrSignature)
+	}
+
+	for _, data := range []string{
+		`{"manifest": {"version": 1}, "signature": "not base64"}`,
+		`{"manifest": {"version": 1}}`,
+	} {
+		if _, err := Verify([]byte(data), public); !errors.Is(err, ErrSignature) {
+			t.Errorf("Verify(%s) error = %v, want %v", data, err, ErrSignature)
+		}
+	}
+	if _, err := Verify([]byte("not json"), public); err == nil {
+		t.Error("Verify() accepted a document that is not JSON")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	public, _ := newKey(t)
+	key, err := ParseKey(base64.StdEncoding.EncodeToString(public))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equal(public) {
+		t.Error("ParseKey() returned another key")
+	}
+
+	if _, err := ParseKey(""); !errors.Is(err, ErrNoKey) {
+		t.Errorf("ParseKey(\"\") error = %v, want %v", err, ErrNoKey)
+	}
+	for _, key := range []string{"not base64", base64.StdEncoding.EncodeToString(public[:16])} {
+		if _, err := ParseKey(key); err == nil {
+			t.Errorf("ParseKey(%q) accepted", key)
+		}
+	}
+}
+
+func TestReplaces(t *testing.T) {
+	tests := []struct {
+		fetched int
+		cached  *Manifest
+		want    bool
+	}{
+		{fetched: 1, cached: nil, want: true},
+		{fetched: 3, cached: &Manifest{Version: 2}, want: true},
+		{fetched: 3, cached: &Manifest{Version: 3}, want: true},
+		{fetched: 2, cached: &Manifest{Version: 3}, want: false},
+	}
+	for _, tt := range tests {
+		if got := Replaces(&Manifest{Version: tt.fetched}, tt.cached); got != tt.want {
+			t.Errorf("Replaces(%d, %+v) = %v, want %v", tt.fetched, tt.cached, got, tt.want)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	public, private := newKey(t)
+	signed, err := Sign([]byte(document), private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest.json":
+			w.Write(signed)
+		case "/large.json":
+			w.Write(bytes.Repeat([]byte(" "), maxSize+1))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	m, data, err := Fetch(context.Background(), server.Client(), server.URL+"/manifest.json", public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != 3 || !bytes.Equal(data, signed) {
+		t.Errorf("Fetch() = %+v, %s", m, data)
+	}
+	// The cached copy verifies again on the next start.
+	if _, err := Verify(data, public); err != nil {
+		t.Error(err)
+	}
+
+	for _, path := range []string{"/missing.json", "/large.json"} {
+		if _, _, err := Fetch(context.Background(), server.Client(), server.URL+path, public); err == nil {
+			t.Errorf("Fetch(%s) succeeded", path)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package network
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Monitor probes connectivity from a single goroutine. It waits
+// OnlineInterval between probes while online and backs off exponentially
+// from MinBackoff to MaxBackoff while offline. Subscribers are called from
+// the monitor goroutine whenever the status changes.
+type Monitor struct {
+	OnlineInterval time.Duration
+	MinBackoff     time.Duration
+	MaxBackoff     time.Duration
+
+	probe func(ctx context.Context) Result
+	wake  chan struct{}
+
+	mu          sync.Mutex
+	result      Result
+	subscribers []func(Result)
+}
+
+func NewMonitor(probe func(ctx context.Context) Result, onlineInterval, minBackoff, maxBackoff time.Duration) *Monitor {
+	return &Monitor{
+		OnlineInterval: onlineInterval,
+		MinBackoff:     minBackoff,
+		MaxBackoff:     maxBackoff,
+		probe:          probe,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+func (m *Monitor) Result() Result {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.result
+}
+
+func (m *Monitor) Subscribe(f func(Result)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscribers = append(m.subscribers, f)
+}
+
+// Recheck probes again right away, for example after the proxy changed.
+func (m *Monitor) Recheck() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Monitor) set(result Result) {
+	m.mu.Lock()
+	changed := result.Status != m.result.Status
+	m.result = result
+	subscribers := append([]func(Result){}, m.subscribers...)
+	m.mu.Unlock()
+
+	if changed {
+		for _, f := range subscribers {
+			f(result)
+		}
+	}
+}
+
+// Run probes until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	backoff := m.MinBackoff
+	for {
+		result := m.probe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		m.set(result)
+
+		var wait time.Duration
+		switch {
+		case result.Status == StatusOnline:
+			wait = m.OnlineInterval
+			backoff = m.MinBackoff
+		case result.Status == StatusRateLimited && time.Until(result.Reset) > 0:
+			wait = min(time.Until(result.Reset), m.MaxBackoff)
+		default:
+			wait = backoff
+			backoff = min(backoff*2, m.MaxBackoff)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-m.wake:
+			timer.Stop()
+			backoff = m.MinBackoff
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package network
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"p86l/internal/version"
+	"strconv"
+	"time"
+)
+
+type Status int
+
+const (
+	StatusUnknown Status = iota
+	StatusOnline
+	StatusOffline
+	StatusGitHubUnreachable
+	StatusRateLimited
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOnline:
+		return "online"
+	case StatusOffline:
+		return "no network"
+	case StatusGitHubUnreachable:
+		return "GitHub unreachable"
+	case StatusRateLimited:
+		return "rate limited"
+	}
+	return "unknown"
+}
+
+// Result is the outcome of a probe. Reset is when the GitHub rate limit
+// resets and is only set for StatusRateLimited.
+type Result struct {
+	Status Status
+	Reset  time.Time
+}
+
+// Prober tells apart a missing network, an unreachable GitHub and a
+// GitHub that refuses requests because of its rate limit.
+type Prober struct {
+	Client *http.Client
+	// GitHubURL is requested first. A failure is followed by the fallback
+	// URLs to find out whether any network is available at all.
+	GitHubURL    string
+	FallbackURLs []string
+}
+
+func (p *Prober) get(ctx context.Context, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	return p.Client.Do(req)
+}
+
+func (p *Prober) Probe(ctx context.Context) Result {
+	resp, err := p.get(ctx, p.GitHubURL)
+	if err == nil {
+		defer resp.Body.Close()
+		if limited, reset := rateLimited(resp); limited {
+			return Result{Status: StatusRateLimited, Reset: reset}
+		}
+		if resp.StatusCode < http.StatusInternalServerError {
+			return Result{Status: StatusOnline}
+		}
+	}
+
+	for _, fallback := range p.FallbackURLs {
+		resp, err := p.get(ctx, fallback)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		return Result{Status: StatusGitHubUnreachable}
+	}
+	return Result{Status: StatusOffline}
+}
+
+func rateLimited(resp *http.Response) (bool, time.Time) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false, time.Time{}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" && resp.StatusCode != http.StatusTooManyRequests {
+		return false, time.Time{}
+	}
+	var reset time.Time
+	if unix, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(unix, 0)
+	}
+	return true, reset
+}
+
+// ParseProxy checks a proxy setting. An empty string means the proxy comes
+// from the HTTP_PROXY and HTTPS_PROXY environment variables.
+func ParseProxy(proxy string) (*url.URL, error) {
+	if proxy == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.New("proxy must be an http, https or socks5 URL")
+	}
+	if u.Host == "" {
+		return nil, errors.New("proxy has no host")
+	}
+	return u, nil
+}
+
+// NewTransport returns a transport asking proxy for the proxy setting on
+// every request, so a changed setting applies without a restart.
+func NewTransport(proxy func() string) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if u, err := ParseProxy(proxy()); err != nil || u != nil {
+			return u, err
+		}
+		return http.ProxyFromEnvironment(req)
+	}
+	return transport
+}
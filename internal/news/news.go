@@ -0,0 +1,341 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package news reads the announcement feed shown on Home. JSON Feed, RSS
+// 2.0 and Atom are understood, served over HTTP or from a local file.
+package news
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"p86l/internal/download"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ErrUnknownFormat is returned for feeds that are neither JSON Feed, RSS
+// nor Atom.
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+const (
+	maxFeedSize      = 4 << 20
+	maxThumbnailSize = 2 << 20
+	// maxThumbnailDimension bounds the width and height of thumbnails.
+	// Home draws them at most three units wide, a few hundred pixels at the
+	// largest scale, and a small file can claim dimensions that take
+	// gigabytes to decode.
+	maxThumbnailDimension = 1024
+)
+
+type Item struct {
+	// ID is stable across fetches and keys the read state.
+	ID        string
+	Title     string
+	URL       string
+	Published time.Time
+	// Thumbnail is an absolute URL or file path, or empty.
+	Thumbnail string
+}
+
+// IsRemote reports whether source is fetched over HTTP rather than read
+// from a file.
+func IsRemote(source string) bool {
+	u, err := url.Parse(source)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+// open reads an http(s) URL, a file:// URL or a file path.
+func open(ctx context.Context, client *http.Client, source string) (io.ReadCloser, error) {
+	if IsRemote(source) {
+		body, _, err := download.Open(ctx, client, source)
+		return body, err
+	}
+	if u, err := url.Parse(source); err == nil && u.Scheme == "file" {
+		source = u.Path
+	}
+	return os.Open(source)
+}
+
+// Fetch reads and parses the feed at source, newest item first.
+func Fetch(ctx context.Context, client *http.Client, source string) ([]Item, error) {
+	body, err := open(ctx, client, source)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxFeedSize))
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, source)
+}
+
+// Parse reads a feed. Relative links are resolved against base, the
+// address the feed came from.
+func Parse(data []byte, base string) ([]Item, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("\xef\xbb\xbf"))
+	var (
+		items []Item
+		err   error
+	)
+	if bytes.HasPrefix(data, []byte("{")) {
+		items, err = parseJSON(data)
+	} else {
+		items, err = parseXML(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		item := &items[i]
+		item.Title = strings.TrimSpace(item.Title)
+		item.URL = resolve(base, strings.TrimSpace(item.URL))
+		item.Thumbnail = resolve(base, strings.TrimSpace(item.Thumbnail))
+		if item.ID == "" {
+			item.ID = item.URL
+		}
+		if item.ID == "" {
+			item.ID = item.Title + "@" + item.Published.Format(time.RFC3339)
+		}
+	}
+	items = slices.DeleteFunc(items, func(item Item) bool {
+		return item.Title == ""
+	})
+	slices.SortStableFunc(items, func(a, b Item) int {
+		return b.Published.Compare(a.Published)
+	})
+	return items, nil
+}
+
+// resolve makes ref absolute. Feeds read from files resolve relative refs
+// against the file directory; remote feeds may only point at the web.
+func resolve(base, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	if IsRemote(base) {
+		baseURL, err := url.Parse(base)
+		refURL, refErr := url.Parse(ref)
+		if err != nil || refErr != nil {
+			return ""
+		}
+		if resolved := baseURL.ResolveReference(refURL).String(); IsRemote(resolved) {
+			return resolved
+		}
+		return ""
+	}
+	if u, err := url.Parse(ref); err == nil && u.IsAbs() {
+		return ref
+	}
+	if filepath.IsAbs(ref) || base == "" {
+		return ref
+	}
+	if u, err := url.Parse(base); err == nil && u.Scheme == "file" {
+		base = u.Path
+	}
+	return filepath.Join(filepath.Dir(base), filepath.FromSlash(ref))
+}
+
+// parseJSON reads a JSON Feed, https://www.jsonfeed.org/version/1.1/.
+func parseJSON(data []byte) ([]Item, error) {
+	var feed struct {
+		Items []struct {
+			ID            string `json:"id"`
+			URL           string `json:"url"`
+			Title         string `json:"title"`
+			Image         string `json:"image"`
+			BannerImage   string `json:"banner_image"`
+			DatePublished string `json:"date_published"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	var items []Item
+	for _, entry := range feed.Items {
+		thumbnail := entry.Image
+		if thumbnail == "" {
+			thumbnail = entry.BannerImage
+		}
+		items = append(items, Item{
+			ID:        entry.ID,
+			Title:     entry.Title,
+			URL:       entry.URL,
+			Published: parseTime(entry.DatePublished),
+			Thumbnail: thumbnail,
+		})
+	}
+	return items, nil
+}
+
+const mediaNS = "http://search.yahoo.com/mrss/"
+
+type mediaRef struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+type xmlLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type xmlFeed struct {
+	XMLName xml.Name
+	// RSS 2.0
+	Items []struct {
+		Title     string     `xml:"title"`
+		Link      string     `xml:"link"`
+		GUID      string     `xml:"guid"`
+		PubDate   string     `xml:"pubDate"`
+		Enclosure []mediaRef `xml:"enclosure"`
+		Thumbnail []mediaRef `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+		Content   []mediaRef `xml:"http://search.yahoo.com/mrss/ content"`
+	} `xml:"channel>item"`
+	// Atom
+	Entries []struct {
+		ID        string     `xml:"id"`
+		Title     string     `xml:"title"`
+		Updated   string     `xml:"updated"`
+		Published string     `xml:"published"`
+		Links     []xmlLink  `xml:"link"`
+		Thumbnail []mediaRef `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	} `xml:"entry"`
+}
+
+func parseXML(data []byte) ([]Item, error) {
+	var feed xmlFeed
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	// Feeds in other encodings are rare; read them as UTF-8.
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	if err := decoder.Decode(&feed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+
+	var items []Item
+	switch feed.XMLName.Local {
+	case "rss":
+		for _, entry := range feed.Items {
+			item := Item{ID: entry.GUID, Title: entry.Title, URL: entry.Link, Published: parseTime(entry.PubDate)}
+			for _, ref := range slices.Concat(entry.Content, entry.Enclosure) {
+				if strings.HasPrefix(ref.Type, "image/") || ref.Medium == "image" {
+					item.Thumbnail = ref.URL
+					break
+				}
+			}
+			if len(entry.Thumbnail) > 0 {
+				item.Thumbnail = entry.Thumbnail[0].URL
+			}
+			items = append(items, item)
+		}
+	case "feed":
+		for _, entry := range feed.Entries {
+			published := entry.Published
+			if published == "" {
+				published = entry.Updated
+			}
+			item := Item{ID: entry.ID, Title: entry.Title, Published: parseTime(published)}
+			for _, link := range entry.Links {
+				switch {
+				case (link.Rel == "" || link.Rel == "alternate") && item.URL == "":
+					item.URL = link.Href
+				case link.Rel == "enclosure" && strings.HasPrefix(link.Type, "image/") && item.Thumbnail == "":
+					item.Thumbnail = link.Href
+				}
+			}
+			if len(entry.Thumbnail) > 0 {
+				item.Thumbnail = entry.Thumbnail[0].URL
+			}
+			items = append(items, item)
+		}
+	default:
+		return nil, fmt.Errorf("%w: <%s>", ErrUnknownFormat, feed.XMLName.Local)
+	}
+	return items, nil
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02",
+}
+
+// parseTime reads the dates feeds use, returning the zero time for others.
+func parseTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Thumbnail loads and decodes the image at ref. Images whose header
+// claims more than maxThumbnailDimension pixels on a side are refused
+// before they are decoded.
+func Thumbnail(ctx context.Context, client *http.Client, ref string) (image.Image, error) {
+	body, err := open(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	b, err := io.ReadAll(io.LimitReader(body, maxThumbnailSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxThumbnailSize {
+		return nil, fmt.Errorf("thumbnail %s is larger than %d bytes", ref, maxThumbnailSize)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width > maxThumbnailDimension || config.Height > maxThumbnailDimension {
+		return nil, fmt.Errorf("thumbnail %s is %dx%d, more than %d pixels on a side", ref, config.Width, config.Height, maxThumbnailDimension)
+	}
+	img, _, err := image.Decode(bytes.NewReader(b))
+	return img, err
+}
@@ -0,0 +1,269 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package news
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="ISO-8859-1"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+	<item>
+		<title>Older</title>
+		<link>https://example.com/older</link>
+		<guid>older-1</guid>
+		<pubDate>Mon, 2 Jun 2025 10:00:00 +0000</pubDate>
+		<enclosure url="https://example.com/older.mp3" type="audio/mpeg"/>
+		<enclosure url="https://example.com/older.png" type="image/png"/>
+	</item>
+	<item>
+		<title> Newer </title>
+		<link>posts/newer.html</link>
+		<pubDate>Tue, 03 Jun 2025 10:00:00 GMT</pubDate>
+		<media:thumbnail url="img/newer.jpg"/>
+	</item>
+	<item>
+		<title></title>
+		<link>https://example.com/untitled</link>
+	</item>
+</channel>
+</rss>`
+
+const atomFeed = `<feed xmlns="http://www.w3.org/2005/Atom">
+	<entry>
+		<id>tag:example.com,2025:1</id>
+		<title>Patch notes</title>
+		<updated>2025-06-01T12:00:00Z</updated>
+		<link rel="self" href="https://example.com/self"/>
+		<link href="https://example.com/patch"/>
+		<link rel="enclosure" type="image/webp" href="https://example.com/patch.webp"/>
+	</entry>
+	<entry>
+		<title>Release</title>
+		<published>2025-06-05T12:00:00Z</published>
+		<updated>2025-06-06T12:00:00Z</updated>
+	</entry>
+</feed>`
+
+const jsonFeed = "\xef\xbb\xbf" + `{
+	"version": "https://jsonfeed.org/version/1.1",
+	"items": [
+		{"id": "1", "title": "Banner", "url": "https://example.com/1", "banner_image": "banner.png", "date_published": "2025-06-01"},
+		{"id": "2", "title": "Image", "url": "https://example.com/2", "image": "/abs/image.png", "date_published": "2025-06-02T00:00:00+02:00"}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		base string
+		want []Item
+	}{
+		{
+			name: "rss",
+			data: rssFeed,
+			base: "https://example.com/feed.xml",
+			want: []Item{
+				{
+					ID:        "https://example.com/posts/newer.html",
+					Title:     "Newer",
+					URL:       "https://example.com/posts/newer.html",
+					Published: time.Date(2025, 6, 3, 10, 0, 0, 0, time.UTC),
+					Thumbnail: "https://example.com/img/newer.jpg",
+				},
+				{
+					ID:        "older-1",
+					Title:     "Older",
+					URL:       "https://example.com/older",
+					Published: time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC),
+					Thumbnail: "https://example.com/older.png",
+				},
+			},
+		},
+		{
+			name: "atom",
+			data: atomFeed,
+			base: "https://example.com/atom.xml",
+			want: []Item{
+				{
+					ID:        "Release@2025-06-05T12:00:00Z",
+					Title:     "Release",
+					Published: time.Date(2025, 6, 5, 12, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:        "tag:example.com,2025:1",
+					Title:     "Patch notes",
+					URL:       "https://example.com/patch",
+					Published: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+					Thumbnail: "https://example.com/patch.webp",
+				},
+			},
+		},
+		{
+			name: "json from a file",
+			data: jsonFeed,
+			base: filepath.FromSlash("/feeds/news.json"),
+			want: []Item{
+				{
+					ID:        "2",
+					Title:     "Image",
+					URL:       "https://example.com/2",
+					Published: time.Date(2025, 6, 2, 0, 0, 0, 0, time.FixedZone("", 2*60*60)),
+					Thumbnail: "/abs/image.png",
+				},
+				{
+					ID:        "1",
+					Title:     "Banner",
+					URL:       "https://example.com/1",
+					Published: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+					Thumbnail: filepath.FromSlash("/feeds/banner.png"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data), tt.base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Parse() = %d items, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if !got[i].Published.Equal(tt.want[i].Published) {
+					t.Errorf("item %d published %v, want %v", i, got[i].Published, tt.want[i].Published)
+				}
+				got[i].Published, tt.want[i].Published = time.Time{}, time.Time{}
+				if got[i] != tt.want[i] {
+					t.Errorf("item %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRemoteRefs(t *testing.T) {
+	// A remote feed must not point the launcher at local files.
+	data := `{"items": [{"title": "Local", "url": "file:///etc/passwd", "image": "/etc/hostname"}]}`
+	items, err := Parse([]byte(data), "https://example.com/feed.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Parse() = %d items, want 1", len(items))
+	}
+	if items[0].URL != "" {
+		t.Errorf("URL = %q, want none", items[0].URL)
+	}
+	if want := "https://example.com/etc/hostname"; items[0].Thumbnail != want {
+		t.Errorf("Thumbnail = %q, want %q", items[0].Thumbnail, want)
+	}
+}
+
+func TestParseUnknownFormat(t *testing.T) {
+	for _, data := range []string{`<html><body/></html>`, `{"items": 1}`, `not a feed`} {
+		if _, err := Parse([]byte(data), ""); !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("Parse(%q) error = %v, want %v", data, err, ErrUnknownFormat)
+		}
+	}
+}
+
+func TestFetchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.xml")
+	if err := os.WriteFile(path, []byte(rssFeed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, source := range []string{path, "file://" + filepath.ToSlash(path)} {
+		items, err := Fetch(context.Background(), nil, source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("Fetch(%q) = %d items, want 2", source, len(items))
+		}
+		if want := filepath.Join(dir, "img", "newer.jpg"); items[0].Thumbnail != want {
+			t.Errorf("Fetch(%q) thumbnail = %q, want %q", source, items[0].Thumbnail, want)
+		}
+	}
+}
+
+// pngSize encodes a w by h image, then claims claimW by claimH in its
+// header when they differ, like a decompression bomb.
+func pngSize(t *testing.T, w, h, claimW, claimH int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	// The IHDR chunk follows the 8 byte signature: length, type, width,
+	// height, 5 more bytes, then the CRC of type and data.
+	binary.BigEndian.PutUint32(b[16:], uint32(claimW))
+	binary.BigEndian.PutUint32(b[20:], uint32(claimH))
+	binary.BigEndian.PutUint32(b[29:], crc32.ChecksumIEEE(b[12:29]))
+	return b
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"small", pngSize(t, 32, 16, 32, 16), false},
+		{"largest", pngSize(t, maxThumbnailDimension, 1, maxThumbnailDimension, 1), false},
+		{"too wide", pngSize(t, maxThumbnailDimension+1, 1, maxThumbnailDimension+1, 1), true},
+		{"too tall", pngSize(t, 1, maxThumbnailDimension+1, 1, maxThumbnailDimension+1), true},
+		{"bomb", pngSize(t, 1, 1, 100000, 100000), true},
+		{"too large", append(pngSize(t, 32, 16, 32, 16), make([]byte, maxThumbnailSize)...), true},
+		{"not an image", []byte("<html></html>"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "thumbnail.png")
+			if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			img, err := Thumbnail(context.Background(), nil, path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Thumbnail() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && img.Bounds().Dx() == 0 {
+				t.Error("Thumbnail() returned an empty image")
+			}
+		})
+	}
+}
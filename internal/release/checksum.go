@@ -0,0 +1,99 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrNoChecksum = errors.New("release has no checksum")
+
+// IsChecksum reports whether an asset holds checksums of the other assets.
+func IsChecksum(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".sha256") || strings.HasSuffix(name, "sums.txt") || name == "sha256sums"
+}
+
+// Checksum returns the SHA-256 digest of asset. It comes from the source
+// itself when it has one, otherwise from a "<name>.sha256" asset or a
+// checksum list of the release with a "digest  name" line for the asset.
+func Checksum(ctx context.Context, source ReleaseSource, assets []*Asset, asset *Asset) (string, error) {
+	if asset.SHA256 != "" {
+		return strings.ToLower(asset.SHA256), nil
+	}
+
+	// The asset's own checksum file goes first, then the shared lists.
+	var checksums []*Asset
+	for _, a := range assets {
+		if !IsChecksum(a.Name) {
+			continue
+		}
+		if a.Name == asset.Name+".sha256" {
+			checksums = append([]*Asset{a}, checksums...)
+		} else {
+			checksums = append(checksums, a)
+		}
+	}
+	if len(checksums) == 0 {
+		return "", fmt.Errorf("%w for %s", ErrNoChecksum, asset.Name)
+	}
+
+	for _, checksum := range checksums {
+		digest, err := readChecksum(ctx, source, checksum, asset.Name)
+		if err != nil {
+			return "", err
+		}
+		if digest != "" {
+			return digest, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s not listed in %s", ErrNoChecksum, asset.Name, checksums[0].Name)
+}
+
+// readChecksum looks up name in a checksum file. A bare digest is only
+// trusted when the file is named after the asset, since a shared list
+// without names cannot tell which asset a digest belongs to.
+func readChecksum(ctx context.Context, source ReleaseSource, checksum *Asset, name string) (string, error) {
+	body, _, err := source.OpenAsset(ctx, checksum)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	own := checksum.Name == name+".sha256"
+	scanner := bufio.NewScanner(io.LimitReader(body, 1<<20))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 1 && own:
+			return strings.ToLower(fields[0]), nil
+		case len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == name:
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", scanner.Err()
+}
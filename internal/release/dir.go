@@ -0,0 +1,133 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// DirReleaseFile holds the optional IndexRelease metadata of a release in a
+// DirSource. Its assets are ignored.
+const DirReleaseFile = "release.json"
+
+// DirSource reads releases from a local folder, such as a USB drive or a
+// build output, with one sub-folder per tag holding the assets.
+type DirSource struct {
+	Dir string
+}
+
+func (s *DirSource) ListReleases(ctx context.Context) ([]*Release, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var releases []*Release
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		release, err := s.release(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	sortReleases(releases)
+	return releases, nil
+}
+
+func (s *DirSource) GetRelease(ctx context.Context, tag string) (*Release, error) {
+	if tag == "" || tag == "." || tag == ".." || filepath.Base(tag) != tag {
+		return nil, fmt.Errorf("%w: invalid tag %q", ErrNoRelease, tag)
+	}
+	if info, err := os.Stat(filepath.Join(s.Dir, tag)); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s has no release %s", ErrNoRelease, s.Dir, tag)
+	}
+	return s.release(tag)
+}
+
+// release reads the metadata and assets of the tag folder. Without a
+// publish date the folder modification time is used.
+func (s *DirSource) release(tag string) (*Release, error) {
+	dir := filepath.Join(s.Dir, tag)
+	meta := IndexRelease{Tag: tag}
+	b, err := os.ReadFile(filepath.Join(dir, DirReleaseFile))
+	if err == nil {
+		if err := json.Unmarshal(b, &meta); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, DirReleaseFile), err)
+		}
+		meta.Tag = tag
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if meta.PublishedAt.IsZero() {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, err
+		}
+		meta.PublishedAt = info.ModTime()
+	}
+
+	release := meta.release()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == DirReleaseFile {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		release.Assets = append(release.Assets, &Asset{
+			Name: entry.Name(),
+			Size: info.Size(),
+			URL:  filepath.Join(dir, entry.Name()),
+		})
+	}
+	return release, nil
+}
+
+func (s *DirSource) ListAssets(ctx context.Context, release *Release) ([]*Asset, error) {
+	return release.Assets, nil
+}
+
+func (s *DirSource) OpenAsset(ctx context.Context, asset *Asset) (io.ReadCloser, int64, error) {
+	f, err := os.Open(asset.URL)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
@@ -0,0 +1,160 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"p86l/internal/download"
+	"regexp"
+
+	"github.com/google/go-github/v69/github"
+)
+
+type GitHubSource struct {
+	Client *github.Client
+	// HTTPClient downloads assets and follows the API download redirects.
+	HTTPClient *http.Client
+	Owner      string
+	Repo       string
+	// API downloads assets through the API instead of the public download
+	// URL, which is needed for private repositories.
+	API bool
+}
+
+func (s *GitHubSource) ListReleases(ctx context.Context) ([]*Release, error) {
+	var releases []*Release
+	err := s.pages(ctx, func(release *Release) bool {
+		releases = append(releases, release)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// FindRelease only fetches the pages up to the first release matching.
+func (s *GitHubSource) FindRelease(ctx context.Context, match func(*Release) bool) (*Release, error) {
+	var found *Release
+	err := s.pages(ctx, func(release *Release) bool {
+		if match(release) {
+			found = release
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, ErrNoRelease
+	}
+	return found, nil
+}
+
+// pages calls yield with every release, newest first, until it returns
+// false.
+func (s *GitHubSource) pages(ctx context.Context, yield func(*Release) bool) error {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := s.Client.Repositories.ListReleases(ctx, s.Owner, s.Repo, opts)
+		if err != nil {
+			return err
+		}
+		for _, release := range page {
+			if !yield(fromGitHub(release)) {
+				return nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (s *GitHubSource) GetRelease(ctx context.Context, tag string) (*Release, error) {
+	release, _, err := s.Client.Repositories.GetReleaseByTag(ctx, s.Owner, s.Repo, tag)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s/%s has no release %s", ErrNoRelease, s.Owner, s.Repo, tag)
+		}
+		return nil, err
+	}
+	return fromGitHub(release), nil
+}
+
+func (s *GitHubSource) ListAssets(ctx context.Context, release *Release) ([]*Asset, error) {
+	return release.Assets, nil
+}
+
+func (s *GitHubSource) OpenAsset(ctx context.Context, asset *Asset) (io.ReadCloser, int64, error) {
+	if !s.API {
+		return download.Open(ctx, s.HTTPClient, asset.URL)
+	}
+	body, _, err := s.Client.Repositories.DownloadReleaseAsset(ctx, s.Owner, s.Repo, asset.ID, s.HTTPClient)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, asset.Size, nil
+}
+
+func fromGitHub(release *github.RepositoryRelease) *Release {
+	r := &Release{
+		Tag:         release.GetTagName(),
+		Name:        release.GetName(),
+		Body:        release.GetBody(),
+		URL:         release.GetHTMLURL(),
+		Prerelease:  release.GetPrerelease(),
+		Draft:       release.GetDraft(),
+		PublishedAt: release.GetPublishedAt().Time,
+
+		MinLauncherVersion: bodyMinLauncher(release.GetBody()),
+	}
+	for _, asset := range release.Assets {
+		r.Assets = append(r.Assets, &Asset{
+			Name: asset.GetName(),
+			Size: int64(asset.GetSize()),
+			URL:  asset.GetBrowserDownloadURL(),
+			ID:   asset.GetID(),
+		})
+	}
+	return r
+}
+
+// minLauncherLine is a "min_launcher_version: 1.2.0" line of a release
+// body, which may be hidden in an HTML comment.
+var minLauncherLine = regexp.MustCompile(`(?m)^\s*(?:<!--\s*)?min_launcher_version:\s*(v?[0-9][0-9A-Za-z.\-]*)`)
+
+// bodyMinLauncher reads the minimum launcher version GitHub releases carry
+// in their description.
+func bodyMinLauncher(body string) string {
+	if m := minLauncherLine.FindStringSubmatch(body); m != nil {
+		return m[1]
+	}
+	return ""
+}
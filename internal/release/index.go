@@ -0,0 +1,160 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"p86l/internal/download"
+	"slices"
+	"time"
+)
+
+// Index is the JSON document served by an IndexSource. Asset and mirror
+// URLs may be relative to the index; an asset without URL is looked up by
+// name next to it.
+type Index struct {
+	Releases []IndexRelease `json:"releases"`
+}
+
+type IndexRelease struct {
+	Tag         string       `json:"tag"`
+	Name        string       `json:"name,omitempty"`
+	Body        string       `json:"body,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Prerelease  bool         `json:"prerelease,omitempty"`
+	Draft       bool         `json:"draft,omitempty"`
+	PublishedAt time.Time    `json:"published_at"`
+	Assets      []IndexAsset `json:"assets,omitempty"`
+
+	MinLauncherVersion string `json:"min_launcher_version,omitempty"`
+}
+
+type IndexAsset struct {
+	Name    string   `json:"name"`
+	Size    int64    `json:"size,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	SHA256  string   `json:"sha256,omitempty"`
+	Mirrors []string `json:"mirrors,omitempty"`
+}
+
+func (r *IndexRelease) release() *Release {
+	return &Release{
+		Tag:         r.Tag,
+		Name:        r.Name,
+		Body:        r.Body,
+		URL:         r.URL,
+		Prerelease:  r.Prerelease,
+		Draft:       r.Draft,
+		PublishedAt: r.PublishedAt,
+
+		MinLauncherVersion: r.MinLauncherVersion,
+	}
+}
+
+// sortReleases orders releases newest first.
+func sortReleases(releases []*Release) {
+	slices.SortStableFunc(releases, func(a, b *Release) int {
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+}
+
+// IndexSource reads releases from an Index served over HTTP, for mirrors and
+// self-hosted builds.
+type IndexSource struct {
+	Client *http.Client
+	URL    string
+}
+
+func (s *IndexSource) ListReleases(ctx context.Context) ([]*Release, error) {
+	base, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := download.Open(ctx, s.Client, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var index Index
+	if err := json.NewDecoder(io.LimitReader(body, 16<<20)).Decode(&index); err != nil {
+		return nil, fmt.Errorf("release index %s: %w", s.URL, err)
+	}
+
+	var releases []*Release
+	for _, r := range index.Releases {
+		release := r.release()
+		for _, a := range r.Assets {
+			ref := a.URL
+			if ref == "" {
+				ref = url.PathEscape(a.Name)
+			}
+			assetURL, err := base.Parse(ref)
+			if err != nil {
+				return nil, fmt.Errorf("release index %s: asset %s: %w", s.URL, a.Name, err)
+			}
+			asset := &Asset{
+				Name:   a.Name,
+				Size:   a.Size,
+				URL:    assetURL.String(),
+				SHA256: a.SHA256,
+			}
+			for _, mirror := range a.Mirrors {
+				mirrorURL, err := base.Parse(mirror)
+				if err != nil {
+					return nil, fmt.Errorf("release index %s: mirror of %s: %w", s.URL, a.Name, err)
+				}
+				asset.Mirrors = append(asset.Mirrors, mirrorURL.String())
+			}
+			release.Assets = append(release.Assets, asset)
+		}
+		releases = append(releases, release)
+	}
+	sortReleases(releases)
+	return releases, nil
+}
+
+func (s *IndexSource) GetRelease(ctx context.Context, tag string) (*Release, error) {
+	releases, err := s.ListReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if release.Tag == tag {
+			return release, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s has no release %s", ErrNoRelease, s.URL, tag)
+}
+
+func (s *IndexSource) ListAssets(ctx context.Context, release *Release) ([]*Asset, error) {
+	return release.Assets, nil
+}
+
+func (s *IndexSource) OpenAsset(ctx context.Context, asset *Asset) (io.ReadCloser, int64, error) {
+	return download.Open(ctx, s.Client, asset.URL)
+}
@@ -0,0 +1,155 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type Channel string
+
+const (
+	// ChannelStable follows the latest release that is not a pre-release.
+	ChannelStable Channel = "stable"
+	// ChannelBeta follows the latest pre-release.
+	ChannelBeta Channel = "beta"
+	// ChannelPinned stays on a single tag.
+	ChannelPinned Channel = "pinned"
+)
+
+var Channels = []Channel{ChannelStable, ChannelBeta, ChannelPinned}
+
+var ErrNoRelease = errors.New("no release found")
+
+type Release struct {
+	Tag  string
+	Name string
+	Body string
+	// URL is the page describing the release, if there is one.
+	URL         string
+	Prerelease  bool
+	Draft       bool
+	PublishedAt time.Time
+	Assets      []*Asset
+	// MinLauncherVersion is the oldest launcher able to install the
+	// release, or "" when any launcher can.
+	MinLauncherVersion string
+}
+
+type Asset struct {
+	Name string
+	Size int64
+	// URL locates the asset for the source that listed it: a download URL
+	// for GitHub and HTTP indexes, a file path for directories.
+	URL string
+	// ID is the GitHub asset ID, used to download through the API.
+	ID int64
+	// SHA256 is the digest published by the source, if any.
+	SHA256 string
+	// Mirrors are other URLs serving the same file.
+	Mirrors []string
+}
+
+// ReleaseSource is where releases and their assets come from, such as
+// GitHub, a self-hosted index or a local folder.
+type ReleaseSource interface {
+	// ListReleases returns every release, newest first.
+	ListReleases(ctx context.Context) ([]*Release, error)
+	// GetRelease returns the release with tag, or an error wrapping
+	// ErrNoRelease.
+	GetRelease(ctx context.Context, tag string) (*Release, error)
+	ListAssets(ctx context.Context, release *Release) ([]*Asset, error)
+	// OpenAsset streams an asset. The size is -1 when it is unknown.
+	OpenAsset(ctx context.Context, asset *Asset) (io.ReadCloser, int64, error)
+}
+
+// Resolve returns the release a channel currently points at. pinnedTag is
+// only used by ChannelPinned.
+func Resolve(ctx context.Context, source ReleaseSource, channel Channel, pinnedTag string) (*Release, error) {
+	var prerelease bool
+	switch channel {
+	case ChannelStable, "":
+	case ChannelBeta:
+		prerelease = true
+	case ChannelPinned:
+		if pinnedTag == "" {
+			return nil, fmt.Errorf("%w: pinned channel without a tag", ErrNoRelease)
+		}
+		return source.GetRelease(ctx, pinnedTag)
+	default:
+		return nil, fmt.Errorf("unknown release channel %q", channel)
+	}
+
+	release, err := findRelease(ctx, source, func(release *Release) bool {
+		return release.Prerelease == prerelease && !release.Draft
+	})
+	if errors.Is(err, ErrNoRelease) && prerelease {
+		return nil, fmt.Errorf("%w: no pre-release", ErrNoRelease)
+	}
+	return release, err
+}
+
+// releaseFinder is a ReleaseSource that lists releases in pages, so it can
+// stop at the first match instead of fetching them all.
+type releaseFinder interface {
+	// FindRelease returns the newest release matching, or an error
+	// wrapping ErrNoRelease.
+	FindRelease(ctx context.Context, match func(*Release) bool) (*Release, error)
+}
+
+func findRelease(ctx context.Context, source ReleaseSource, match func(*Release) bool) (*Release, error) {
+	if finder, ok := source.(releaseFinder); ok {
+		return finder.FindRelease(ctx, match)
+	}
+	releases, err := source.ListReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if match(release) {
+			return release, nil
+		}
+	}
+	return nil, ErrNoRelease
+}
+
+// HTTPAsset returns the URL and client source downloads asset with when it
+// is a plain HTTP GET, so it can also be fetched in ranges. It returns an
+// empty URL for local files and API downloads.
+func HTTPAsset(source ReleaseSource, asset *Asset) (string, *http.Client) {
+	switch s := source.(type) {
+	case *GitHubSource:
+		if !s.API {
+			return asset.URL, s.HTTPClient
+		}
+	case *IndexSource:
+		return asset.URL, s.Client
+	case *Withdrawn:
+		return HTTPAsset(s.Source, asset)
+	}
+	return "", nil
+}
@@ -0,0 +1,266 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v69/github"
+)
+
+// writeRelease lays out a DirSource release folder with the given files.
+func writeRelease(t *testing.T, dir string, meta IndexRelease, files map[string]string) {
+	t.Helper()
+	releaseDir := filepath.Join(dir, meta.Tag)
+	if err := os.MkdirAll(releaseDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(releaseDir, DirReleaseFile), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(releaseDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	const (
+		own    = "AAAA"
+		listed = "bbbb"
+	)
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "own file with a bare digest",
+			files: map[string]string{"game.zip.sha256": own + "\n"},
+			want:  "aaaa",
+		},
+		{
+			name:  "own file with a named line",
+			files: map[string]string{"game.zip.sha256": own + "  game.zip\n"},
+			want:  "aaaa",
+		},
+		{
+			name:  "shared list",
+			files: map[string]string{"SHA256SUMS": "cccc  other.zip\n" + listed + " *game.zip\n"},
+			want:  listed,
+		},
+		{
+			name:    "shared list with a bare digest",
+			files:   map[string]string{"SHA256SUMS": listed + "\n"},
+			wantErr: ErrNoChecksum,
+		},
+		{
+			name:    "other asset's file",
+			files:   map[string]string{"other.zip.sha256": listed + "\n"},
+			wantErr: ErrNoChecksum,
+		},
+		{
+			name: "own file before the shared list",
+			files: map[string]string{
+				"SHA256SUMS":      listed + "  game.zip\n",
+				"game.zip.sha256": own + "\n",
+			},
+			want: "aaaa",
+		},
+		{
+			name: "second list",
+			files: map[string]string{
+				"SHA256SUMS": "cccc  other.zip\n",
+				"sums.txt":   listed + "  game.zip\n",
+			},
+			want: listed,
+		},
+		{
+			name:    "no checksum",
+			wantErr: ErrNoChecksum,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{"game.zip": "game"}
+			for name, content := range tt.files {
+				files[name] = content
+			}
+			writeRelease(t, dir, IndexRelease{Tag: "v1.0.0"}, files)
+
+			source := &DirSource{Dir: dir}
+			release, err := source.GetRelease(context.Background(), "v1.0.0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var asset *Asset
+			for _, a := range release.Assets {
+				if a.Name == "game.zip" {
+					asset = a
+				}
+			}
+
+			got, err := Checksum(context.Background(), source, release.Assets, asset)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Checksum() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Checksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksumFromSource(t *testing.T) {
+	asset := &Asset{Name: "game.zip", SHA256: "ABCD"}
+	got, err := Checksum(context.Background(), &DirSource{}, []*Asset{asset}, asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "abcd" {
+		t.Errorf("Checksum() = %q, want %q", got, "abcd")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, meta := range []IndexRelease{
+		{Tag: "v1.0.0", PublishedAt: now},
+		{Tag: "v1.1.0-beta.1", Prerelease: true, PublishedAt: now.Add(time.Hour)},
+		{Tag: "v1.1.0", PublishedAt: now.Add(2 * time.Hour)},
+		{Tag: "v1.2.0-beta.1", Prerelease: true, PublishedAt: now.Add(3 * time.Hour)},
+		{Tag: "v1.2.0", Draft: true, PublishedAt: now.Add(4 * time.Hour)},
+	} {
+		writeRelease(t, dir, meta, nil)
+	}
+	source := &DirSource{Dir: dir}
+
+	tests := []struct {
+		channel Channel
+		pinned  string
+		want    string
+		wantErr error
+	}{
+		{channel: "", want: "v1.1.0"},
+		{channel: ChannelStable, want: "v1.1.0"},
+		{channel: ChannelBeta, want: "v1.2.0-beta.1"},
+		{channel: ChannelPinned, pinned: "v1.0.0", want: "v1.0.0"},
+		{channel: ChannelPinned, wantErr: ErrNoRelease},
+		{channel: ChannelPinned, pinned: "v9.9.9", wantErr: ErrNoRelease},
+		{channel: ChannelPinned, pinned: "../v1.0.0", wantErr: ErrNoRelease},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.channel)+"/"+tt.pinned, func(t *testing.T) {
+			got, err := Resolve(context.Background(), source, tt.channel, tt.pinned)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Tag != tt.want {
+				t.Errorf("Resolve() = %s, want %s", got.Tag, tt.want)
+			}
+		})
+	}
+
+	if _, err := Resolve(context.Background(), source, "nightly", ""); err == nil {
+		t.Error("Resolve() accepted an unknown channel")
+	}
+}
+
+func TestResolveGitHubPages(t *testing.T) {
+	// Three pages of one release each: a pre-release, a stable release
+	// and an older stable release.
+	pages := []string{
+		`[{"tag_name": "v1.1.0-beta.1", "prerelease": true}]`,
+		`[{"tag_name": "v1.0.0"}]`,
+		`[{"tag_name": "v0.9.0"}]`,
+	}
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		if page < len(pages) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next"`, "http://"+r.Host, r.URL.Path, page+1))
+		}
+		fmt.Fprint(w, pages[page-1])
+	}))
+	defer server.Close()
+
+	client := github.NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	source := &GitHubSource{Client: client, Owner: "owner", Repo: "repo"}
+
+	tests := []struct {
+		source       ReleaseSource
+		channel      Channel
+		want         string
+		wantRequests int
+	}{
+		{source: source, channel: ChannelBeta, want: "v1.1.0-beta.1", wantRequests: 1},
+		{source: source, channel: ChannelStable, want: "v1.0.0", wantRequests: 2},
+		{source: &Withdrawn{Source: source, Tags: []string{"v1.0.0"}}, channel: ChannelStable, want: "v0.9.0", wantRequests: 3},
+	}
+	for _, tt := range tests {
+		requests = 0
+		got, err := Resolve(context.Background(), tt.source, tt.channel, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Tag != tt.want {
+			t.Errorf("Resolve(%s) = %s, want %s", tt.channel, got.Tag, tt.want)
+		}
+		if requests != tt.wantRequests {
+			t.Errorf("Resolve(%s) fetched %d pages, want %d", tt.channel, requests, tt.wantRequests)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Withdrawn hides the releases of Source tagged with one of Tags, pulled
+// after they shipped broken.
+type Withdrawn struct {
+	Source ReleaseSource
+	Tags   []string
+}
+
+func (s *Withdrawn) ListReleases(ctx context.Context) ([]*Release, error) {
+	releases, err := s.Source.ListReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(releases, func(release *Release) bool {
+		return slices.Contains(s.Tags, release.Tag)
+	}), nil
+}
+
+func (s *Withdrawn) FindRelease(ctx context.Context, match func(*Release) bool) (*Release, error) {
+	return findRelease(ctx, s.Source, func(release *Release) bool {
+		return !slices.Contains(s.Tags, release.Tag) && match(release)
+	})
+}
+
+func (s *Withdrawn) GetRelease(ctx context.Context, tag string) (*Release, error) {
+	if slices.Contains(s.Tags, tag) {
+		return nil, fmt.Errorf("%w: %s was withdrawn", ErrNoRelease, tag)
+	}
+	return s.Source.GetRelease(ctx, tag)
+}
+
+func (s *Withdrawn) ListAssets(ctx context.Context, release *Release) ([]*Asset, error) {
+	return s.Source.ListAssets(ctx, release)
+}
+
+func (s *Withdrawn) OpenAsset(ctx context.Context, asset *Asset) (io.ReadCloser, int64, error) {
+	return s.Source.OpenAsset(ctx, asset)
+}
@@ -0,0 +1,133 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
+// SplitArgs splits a command line typed by the user into arguments. Spaces
+// separate arguments unless quoted. Single quotes keep everything; double
+// quotes allow \" and \\. Other backslashes are kept, for Windows paths.
+func SplitArgs(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+				continue
+			}
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+				continue
+			}
+			if c == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+				c = runes[i]
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+			continue
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+			continue
+		}
+		arg.WriteRune(c)
+		inArg = true
+	}
+	if quote != 0 {
+		return nil, ErrUnterminatedQuote
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// JoinArgs is the inverse of SplitArgs, quoting only where needed.
+func JoinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == "":
+			quoted[i] = "''"
+		case !strings.ContainsAny(arg, " \t\n'\""):
+			quoted[i] = arg
+		case !strings.Contains(arg, "'"):
+			quoted[i] = "'" + arg + "'"
+		default:
+			quoted[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ParseEnv parses KEY=value pairs split like SplitArgs, so values may hold
+// quoted spaces. It returns nil for an empty line.
+func ParseEnv(line string) (map[string]string, error) {
+	pairs, err := SplitArgs(line)
+	if err != nil {
+		return nil, err
+	}
+	var env map[string]string
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not KEY=value", pair)
+		}
+		if env == nil {
+			env = map[string]string{}
+		}
+		env[key] = value
+	}
+	if err := ValidateEnv(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// FormatEnv is the inverse of ParseEnv, with the keys sorted.
+func FormatEnv(env map[string]string) string {
+	pairs := make([]string, 0, len(env))
+	for key, value := range env {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return JoinArgs(pairs)
+}
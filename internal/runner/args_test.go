@@ -0,0 +1,103 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package runner
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{``, nil},
+		{`  -a  -b `, []string{"-a", "-b"}},
+		{`-name "two words"`, []string{"-name", "two words"}},
+		{`'it''s'`, []string{"its"}},
+		{`"say \"hi\"" 'a\b'`, []string{`say "hi"`, `a\b`}},
+		{`C:\Games\p86.exe`, []string{`C:\Games\p86.exe`}},
+		{`"" x`, []string{"", "x"}},
+		{`a"b c"d`, []string{"ab cd"}},
+	}
+	for _, tt := range tests {
+		got, err := SplitArgs(tt.line)
+		if err != nil {
+			t.Errorf("SplitArgs(%q) error = %v", tt.line, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+
+	for _, line := range []string{`"open`, `'open`, `a "b\"`} {
+		if _, err := SplitArgs(line); !errors.Is(err, ErrUnterminatedQuote) {
+			t.Errorf("SplitArgs(%q) error = %v, want %v", line, err, ErrUnterminatedQuote)
+		}
+	}
+}
+
+func TestJoinArgsRoundTrip(t *testing.T) {
+	for _, args := range [][]string{
+		{"-a", "-b"},
+		{""},
+		{"two words", "tab\there", "new\nline"},
+		{"it's", `say "hi"`, `both ' and "`},
+		{`C:\Program Files\p86`, `trailing\`, `\\server\share`},
+		{`"`, `'`, `\"`},
+	} {
+		line := JoinArgs(args)
+		got, err := SplitArgs(line)
+		if err != nil {
+			t.Errorf("SplitArgs(JoinArgs(%q)) error = %v", args, err)
+			continue
+		}
+		if !slices.Equal(got, args) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", line, got, args)
+		}
+	}
+}
+
+func TestEnvRoundTrip(t *testing.T) {
+	env := map[string]string{"DXVK_HUD": "fps", "EMPTY": "", "SPACED": "a b"}
+	got, err := ParseEnv(FormatEnv(env))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(env) {
+		t.Fatalf("ParseEnv(FormatEnv()) = %q, want %q", got, env)
+	}
+	for key, value := range env {
+		if got[key] != value {
+			t.Errorf("ParseEnv(FormatEnv())[%s] = %q, want %q", key, got[key], value)
+		}
+	}
+
+	for _, line := range []string{"NOVALUE", "=value"} {
+		if _, err := ParseEnv(line); err == nil {
+			t.Errorf("ParseEnv(%q) accepted", line)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package runner starts game builds, either natively or, for Windows
+// builds on Linux, through Wine or Proton in a prefix of their own.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+type Kind string
+
+const (
+	Native Kind = "native"
+	Wine   Kind = "wine"
+	Proton Kind = "proton"
+)
+
+var Kinds = []Kind{Native, Wine, Proton}
+
+// prefixMarker is written into a prefix once it has been initialized.
+const prefixMarker = ".p86l-prefix"
+
+var ErrNoBinary = errors.New("runner binary not found")
+
+// Runner says how an instance starts its game. A nil Runner is native.
+type Runner struct {
+	Kind Kind `json:",omitempty"`
+	// Binary is the wine executable or the proton script. Wine is looked
+	// up on PATH when it is empty; Proton needs it.
+	Binary string `json:",omitempty"`
+	// Prefix replaces the prefix the launcher manages for the instance.
+	Prefix string `json:",omitempty"`
+	// Steam is the Steam client folder Proton takes its runtime from. It
+	// is looked up in the usual places when empty.
+	Steam string `json:",omitempty"`
+	// Env is added to the environment of the runner and the game.
+	Env map[string]string `json:",omitempty"`
+}
+
+func (r *Runner) IsNative() bool {
+	return r == nil || r.Kind == "" || r.Kind == Native
+}
+
+// GOOS is the platform of the builds the runner starts on host.
+func (r *Runner) GOOS(host string) string {
+	if r.IsNative() {
+		return host
+	}
+	return "windows"
+}
+
+// Validate refuses unknown kinds, relative paths and malformed variables.
+func (r *Runner) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.Kind != "" && !slices.Contains(Kinds, r.Kind) {
+		return fmt.Errorf("unknown runner %q", r.Kind)
+	}
+	if r.Binary != "" && !filepath.IsAbs(r.Binary) {
+		return fmt.Errorf("runner binary %q is not an absolute path", r.Binary)
+	}
+	if r.Prefix != "" && !filepath.IsAbs(r.Prefix) {
+		return fmt.Errorf("prefix %q is not an absolute path", r.Prefix)
+	}
+	if r.Steam != "" && !filepath.IsAbs(r.Steam) {
+		return fmt.Errorf("steam folder %q is not an absolute path", r.Steam)
+	}
+	return ValidateEnv(r.Env)
+}
+
+// ValidateEnv refuses variable names that cannot be passed to a process.
+func ValidateEnv(env map[string]string) error {
+	for key := range env {
+		if key == "" || strings.ContainsAny(key, "= \t\n") {
+			return fmt.Errorf("invalid environment variable name %q", key)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) binary() (string, error) {
+	if r.Binary != "" {
+		if _, err := os.Stat(r.Binary); err != nil {
+			return "", fmt.Errorf("%w: %w", ErrNoBinary, err)
+		}
+		return r.Binary, nil
+	}
+	if r.Kind == Proton {
+		return "", fmt.Errorf("%w: proton needs the path of its script", ErrNoBinary)
+	}
+	path, err := exec.LookPath("wine")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNoBinary, err)
+	}
+	return path, nil
+}
+
+// steamDirs are where Steam installs itself, relative to the home folder.
+var steamDirs = []string{
+	filepath.Join(".steam", "steam"),
+	filepath.Join(".local", "share", "Steam"),
+	filepath.Join(".var", "app", "com.valvesoftware.Steam", "data", "Steam"),
+}
+
+// steam returns the Steam client folder, or "" when there is none.
+func (r *Runner) steam() string {
+	if r.Steam != "" {
+		return r.Steam
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	for _, dir := range steamDirs {
+		if info, err := os.Stat(filepath.Join(home, dir)); err == nil && info.IsDir() {
+			return filepath.Join(home, dir)
+		}
+	}
+	return ""
+}
+
+// Vars are the variables the runner adds to the environment of commands
+// run in prefix.
+func (r *Runner) Vars(prefix string) []string {
+	if r == nil {
+		return nil
+	}
+	var env []string
+	switch r.Kind {
+	case Wine:
+		env = append(env, "WINEPREFIX="+prefix, "WINEDEBUG=-all")
+	case Proton:
+		// Proton keeps its wine prefix in "pfx" below the compat data.
+		env = append(env, "STEAM_COMPAT_DATA_PATH="+prefix)
+		// Without a Steam client Proton runs without the Steam runtime.
+		if steam := r.steam(); steam != "" {
+			env = append(env, "STEAM_COMPAT_CLIENT_INSTALL_PATH="+steam)
+		}
+	}
+	keys := make([]string, 0, len(r.Env))
+	for key := range r.Env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		env = append(env, key+"="+r.Env[key])
+	}
+	return env
+}
+
+func (r *Runner) environ(prefix string) []string {
+	return append(os.Environ(), r.Vars(prefix)...)
+}
+
+// IsPrepared reports whether prefix was initialized by Prepare.
+func IsPrepared(prefix string) bool {
+	_, err := os.Stat(filepath.Join(prefix, prefixMarker))
+	return err == nil
+}
+
+// Prepare creates and initializes prefix, unless it already is. Wine
+// prefixes are booted once; Proton sets its prefix up on the first run.
+func (r *Runner) Prepare(ctx context.Context, prefix string) error {
+	if r.IsNative() || IsPrepared(prefix) {
+		return nil
+	}
+	binary, err := r.binary()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(prefix, 0o755); err != nil {
+		return err
+	}
+	if r.Kind == Wine {
+		cmd := exec.CommandContext(ctx, binary, "wineboot", "--init")
+		cmd.Env = r.environ(prefix)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("wineboot: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+	}
+	return os.WriteFile(filepath.Join(prefix, prefixMarker), []byte(string(r.Kind)+" "+binary+"\n"), 0o644)
+}
+
+// Command returns the command starting exe with args in dir, with env
+// added to its environment. Native builds run as they are and macOS apps
+// through open, unless there are variables to pass: open hands the app to
+// Launch Services, which starts it without them, so the bundle executable
+// is run directly instead.
+func (r *Runner) Command(exe, dir, prefix string, args, env []string) (*exec.Cmd, error) {
+	vars := append(r.Vars(prefix), env...)
+	if r.IsNative() {
+		cmd := exec.Command(exe, args...)
+		if runtime.GOOS == "darwin" && strings.HasSuffix(exe, ".app") {
+			if len(vars) == 0 {
+				cmd = exec.Command("open", append([]string{"-W", exe, "--args"}, args...)...)
+			} else {
+				bin, err := bundleExecutable(exe)
+				if err != nil {
+					return nil, err
+				}
+				cmd = exec.Command(bin, args...)
+			}
+		}
+		cmd.Dir = dir
+		if len(vars) > 0 {
+			cmd.Env = append(os.Environ(), vars...)
+		}
+		return cmd, nil
+	}
+	binary, err := r.binary()
+	if err != nil {
+		return nil, err
+	}
+	var cmd *exec.Cmd
+	if r.Kind == Proton {
+		cmd = exec.Command(binary, append([]string{"run", exe}, args...)...)
+	} else {
+		cmd = exec.Command(binary, append([]string{exe}, args...)...)
+	}
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), vars...)
+	return cmd, nil
+}
+
+// bundleExecutablePattern finds the executable name in an XML Info.plist.
+var bundleExecutablePattern = regexp.MustCompile(`<key>CFBundleExecutable</key>\s*<string>([^<]+)</string>`)
+
+// bundleExecutable returns the executable of a macOS app bundle, named in
+// its Info.plist or else after the bundle.
+func bundleExecutable(app string) (string, error) {
+	name := strings.TrimSuffix(filepath.Base(app), ".app")
+	if b, err := os.ReadFile(filepath.Join(app, "Contents", "Info.plist")); err == nil {
+		if m := bundleExecutablePattern.FindSubmatch(b); m != nil {
+			name = strings.TrimSpace(string(m[1]))
+		}
+	}
+	path := filepath.Join(app, "Contents", "MacOS", filepath.Base(name))
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("%s has no executable: %w", app, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,196 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeRunner writes a script that logs its arguments and WINEPREFIX to
+// log, and returns its path.
+func fakeRunner(t *testing.T, dir, name, log string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a shell")
+	}
+	path := filepath.Join(dir, name)
+	script := "#!/bin/sh\necho \"$* $WINEPREFIX\" >> " + log + "\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readLog(t *testing.T, log string) []string {
+	t.Helper()
+	b, err := os.ReadFile(log)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(b)), "\n")
+}
+
+func TestPrepareWine(t *testing.T) {
+	bin := t.TempDir()
+	log := filepath.Join(t.TempDir(), "log")
+	fakeRunner(t, bin, "wine", log)
+	t.Setenv("PATH", bin)
+
+	prefix := filepath.Join(t.TempDir(), "prefix")
+	r := &Runner{Kind: Wine}
+	for range 2 {
+		if err := r.Prepare(context.Background(), prefix); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !IsPrepared(prefix) {
+		t.Error("IsPrepared() = false after Prepare")
+	}
+	// The prefix is booted once.
+	want := []string{"wineboot --init " + prefix}
+	if got := readLog(t, log); !slices.Equal(got, want) {
+		t.Errorf("wine ran %q, want %q", got, want)
+	}
+}
+
+func TestPrepareProton(t *testing.T) {
+	log := filepath.Join(t.TempDir(), "log")
+	r := &Runner{Kind: Proton, Binary: fakeRunner(t, t.TempDir(), "proton", log)}
+	prefix := filepath.Join(t.TempDir(), "prefix")
+	if err := r.Prepare(context.Background(), prefix); err != nil {
+		t.Fatal(err)
+	}
+	if !IsPrepared(prefix) {
+		t.Error("IsPrepared() = false after Prepare")
+	}
+	if got := readLog(t, log); got != nil {
+		t.Errorf("proton ran %q before the first launch", got)
+	}
+}
+
+func TestPrepareMissingBinary(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	for _, r := range []*Runner{{Kind: Wine}, {Kind: Proton}} {
+		if err := r.Prepare(context.Background(), t.TempDir()); err == nil {
+			t.Errorf("Prepare() with %s and no binary succeeded", r.Kind)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	bin := t.TempDir()
+	log := filepath.Join(t.TempDir(), "log")
+	wine := fakeRunner(t, bin, "wine", log)
+	proton := fakeRunner(t, bin, "proton", log)
+	t.Setenv("PATH", bin)
+	// No Steam in the home folder
+	t.Setenv("HOME", t.TempDir())
+
+	prefix := t.TempDir()
+	steam := t.TempDir()
+	tests := []struct {
+		name     string
+		runner   *Runner
+		wantArgs []string
+		wantEnv  []string
+		noEnv    []string
+	}{
+		{
+			name:     "wine",
+			runner:   &Runner{Kind: Wine, Env: map[string]string{"DXVK_HUD": "fps"}},
+			wantArgs: []string{wine, "game.exe", "-x"},
+			wantEnv:  []string{"WINEPREFIX=" + prefix, "DXVK_HUD=fps", "PROFILE=1"},
+		},
+		{
+			name:     "proton without steam",
+			runner:   &Runner{Kind: Proton, Binary: proton},
+			wantArgs: []string{proton, "run", "game.exe", "-x"},
+			wantEnv:  []string{"STEAM_COMPAT_DATA_PATH=" + prefix, "PROFILE=1"},
+			noEnv:    []string{"STEAM_COMPAT_CLIENT_INSTALL_PATH"},
+		},
+		{
+			name:     "proton with steam",
+			runner:   &Runner{Kind: Proton, Binary: proton, Steam: steam},
+			wantArgs: []string{proton, "run", "game.exe", "-x"},
+			wantEnv:  []string{"STEAM_COMPAT_DATA_PATH=" + prefix, "STEAM_COMPAT_CLIENT_INSTALL_PATH=" + steam},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := tt.runner.Command("game.exe", "/games", prefix, []string{"-x"}, []string{"PROFILE=1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(cmd.Args, tt.wantArgs) {
+				t.Errorf("Args = %q, want %q", cmd.Args, tt.wantArgs)
+			}
+			if cmd.Dir != "/games" {
+				t.Errorf("Dir = %q, want /games", cmd.Dir)
+			}
+			for _, v := range tt.wantEnv {
+				if !slices.Contains(cmd.Env, v) {
+					t.Errorf("Env lacks %s", v)
+				}
+			}
+			for _, key := range tt.noEnv {
+				if slices.ContainsFunc(cmd.Env, func(v string) bool { return strings.HasPrefix(v, key+"=") }) {
+					t.Errorf("Env has %s", key)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandNative(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("apps start through open")
+	}
+	var r *Runner
+	cmd, err := r.Command("/games/p86", "/games", "", []string{"-x"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/games/p86", "-x"}; !slices.Equal(cmd.Args, want) {
+		t.Errorf("Args = %q, want %q", cmd.Args, want)
+	}
+	// Without variables the game inherits the launcher environment as is.
+	if cmd.Env != nil {
+		t.Errorf("Env = %q, want nil", cmd.Env)
+	}
+
+	cmd, err = r.Command("/games/p86", "/games", "", nil, []string{"PROFILE=1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(cmd.Env, "PROFILE=1") {
+		t.Error("Env lacks PROFILE=1")
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package secret
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// FileStore keeps each value encrypted in its own file below Dir.
+type FileStore struct {
+	Dir string
+}
+
+func (f *FileStore) Name() string {
+	return "encrypted file"
+}
+
+func (f *FileStore) path(key string) string {
+	return filepath.Join(f.Dir, key+".secret")
+}
+
+func (f *FileStore) Get(key string) (string, error) {
+	b, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	value, err := f.open(b)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func (f *FileStore) Set(key, value string) error {
+	if err := os.MkdirAll(f.Dir, 0700); err != nil {
+		return err
+	}
+	b, err := f.seal([]byte(value))
+	if err != nil {
+		return err
+	}
+	tmp := f.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(key))
+}
+
+func (f *FileStore) Delete(key string) error {
+	if err := os.Remove(f.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package secret
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	store := &FileStore{Dir: filepath.Join(t.TempDir(), "secrets")}
+	if _, err := store.Get("token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() before Set error = %v, want %v", err, ErrNotFound)
+	}
+	for _, value := range []string{"ghp_first", "", "ghp_second ünïcode"} {
+		if err := store.Set("token", value); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.Get("token")
+		if err != nil || got != value {
+			t.Fatalf("Get() = %q, %v, want %q", got, err, value)
+		}
+	}
+
+	// The value is not kept in the clear.
+	b, err := os.ReadFile(store.path("token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("ghp_second")) {
+		t.Error("secret file holds the plain value")
+	}
+	if _, err := os.Stat(store.path("token") + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	if err := store.Delete("token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("token"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want %v", err, ErrNotFound)
+	}
+	if err := store.Delete("token"); err != nil {
+		t.Errorf("Delete() of a missing value = %v", err)
+	}
+}
+
+func TestFileStoreUnreadable(t *testing.T) {
+	tests := []struct {
+		name   string
+		damage func(t *testing.T, store *FileStore)
+	}{
+		{
+			name: "corrupted value",
+			damage: func(t *testing.T, store *FileStore) {
+				b, err := os.ReadFile(store.path("token"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				b[len(b)-1] ^= 0xff
+				if err := os.WriteFile(store.path("token"), b, 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "truncated value",
+			damage: func(t *testing.T, store *FileStore) {
+				if err := os.WriteFile(store.path("token"), []byte{1, 2, 3}, 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "wrong key",
+			damage: func(t *testing.T, store *FileStore) {
+				if runtime.GOOS == "windows" {
+					t.Skip("values are sealed for the user account")
+				}
+				if err := os.WriteFile(filepath.Join(store.Dir, "secret.key"), bytes.Repeat([]byte{7}, 32), 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "invalid key",
+			damage: func(t *testing.T, store *FileStore) {
+				if runtime.GOOS == "windows" {
+					t.Skip("values are sealed for the user account")
+				}
+				if err := os.WriteFile(filepath.Join(store.Dir, "secret.key"), []byte("short"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &FileStore{Dir: t.TempDir()}
+			if err := store.Set("token", "ghp_value"); err != nil {
+				t.Fatal(err)
+			}
+			tt.damage(t, store)
+			key, _ := os.ReadFile(filepath.Join(store.Dir, "secret.key"))
+
+			got, err := store.Get("token")
+			if err == nil || errors.Is(err, ErrNotFound) {
+				t.Fatalf("Get() = %q, %v, want a decryption error", got, err)
+			}
+			// An unreadable value is reported, and the key kept.
+			after, _ := os.ReadFile(filepath.Join(store.Dir, "secret.key"))
+			if !bytes.Equal(after, key) {
+				t.Error("key file was replaced")
+			}
+		})
+	}
+}
+
+func TestFileStorePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no Unix permissions")
+	}
+	store := &FileStore{Dir: filepath.Join(t.TempDir(), "secrets")}
+	if err := store.Set("token", "ghp_value"); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]os.FileMode{
+		store.Dir:                              0700 | os.ModeDir,
+		store.path("token"):                    0600,
+		filepath.Join(store.Dir, "secret.key"): 0600,
+	} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode(); got != want {
+			t.Errorf("%s mode = %v, want %v", filepath.Base(path), got, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+//go:build darwin
+
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package secret
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"p86l/configs"
+	"strings"
+)
+
+// keychainNotFound is the exit status of security when no item matches.
+const keychainNotFound = 44
+
+// Keychain stores values in the login keychain through the security tool.
+type Keychain struct {
+	path string
+}
+
+// systemStore returns the login keychain when the security tool exists.
+func systemStore() Store {
+	path, err := exec.LookPath("security")
+	if err != nil {
+		return nil
+	}
+	return &Keychain{path: path}
+}
+
+func (k *Keychain) Name() string {
+	return "system keyring"
+}
+
+func (k *Keychain) run(stdin string, args ...string) ([]byte, error) {
+	cmd := exec.Command(k.path, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if exitErr.ExitCode() == keychainNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("security %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
+
+func (k *Keychain) Get(key string) (string, error) {
+	out, err := k.run("", "find-generic-password", "-s", configs.AppName, "-a", key, "-w")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set goes through the interactive mode of security so the value is read
+// from stdin and never shows up in the process list.
+func (k *Keychain) Set(key, value string) error {
+	if strings.ContainsAny(value, "\"\\\n") {
+		return errors.New("value cannot be stored in the keychain")
+	}
+	command := fmt.Sprintf("add-generic-password -U -s %q -a %q -w \"%s\"\n", configs.AppName, key, value)
+	_, err := k.run(command, "-i")
+	return err
+}
+
+func (k *Keychain) Delete(key string) error {
+	_, err := k.run("", "delete-generic-password", "-s", configs.AppName, "-a", key)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
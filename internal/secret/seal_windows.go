@@ -0,0 +1,59 @@
+//go:build windows
+
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package secret
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// seal encrypts with DPAPI so only the current Windows user can read the
+// value back.
+func (f *FileStore) seal(value []byte) ([]byte, error) {
+	return dpapi(value, true)
+}
+
+func (f *FileStore) open(b []byte) ([]byte, error) {
+	return dpapi(b, false)
+}
+
+func dpapi(in []byte, protect bool) ([]byte, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	input := windows.DataBlob{Size: uint32(len(in)), Data: &in[0]}
+	var output windows.DataBlob
+	var err error
+	if protect {
+		err = windows.CryptProtectData(&input, nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &output)
+	} else {
+		err = windows.CryptUnprotectData(&input, nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &output)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(output.Data)))
+	return append([]byte(nil), unsafe.Slice(output.Data, output.Size)...), nil
+}
@@ -0,0 +1,72 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package secret keeps credentials out of the plain data files. The system
+// keyring is used when there is one, otherwise values are encrypted on disk.
+package secret
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrNotFound = errors.New("secret not found")
+	// ErrLocked is returned by stores that would have to ask the user to
+	// unlock them before AllowPrompt was called.
+	ErrLocked = errors.New("keyring is locked")
+)
+
+type Store interface {
+	// Name describes where values are kept, for logs and the settings page.
+	Name() string
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// prompter is a Store able to ask the user to unlock it, which it only
+// does once allowed.
+type prompter interface {
+	allowPrompt()
+}
+
+// AllowPrompt lets store ask the user to unlock it, such as with a keyring
+// password dialog. Until then it fails with ErrLocked instead.
+func AllowPrompt(store Store) {
+	if p, ok := store.(prompter); ok {
+		p.allowPrompt()
+	}
+}
+
+// New returns the system keyring when it is available and a FileStore in
+// dir otherwise.
+func New(dir string) Store {
+	if store := systemStore(); store != nil {
+		return store
+	}
+	if runtime.GOOS != "windows" {
+		log.Warn().Str("Dir", dir).Msg("No system keyring, secrets are kept in a file with its key next to it")
+	}
+	return &FileStore{Dir: dir}
+}
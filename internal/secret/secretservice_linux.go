@@ -0,0 +1,245 @@
+//go:build linux
+
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package secret
+
+import (
+	"errors"
+	"p86l/configs"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	ssName              = "org.freedesktop.secrets"
+	ssPath              = "/org/freedesktop/secrets"
+	ssDefaultCollection = "/org/freedesktop/secrets/aliases/default"
+	ssService           = "org.freedesktop.Secret.Service"
+	ssCollection        = "org.freedesktop.Secret.Collection"
+	ssItem              = "org.freedesktop.Secret.Item"
+	ssSession           = "org.freedesktop.Secret.Session"
+	ssPrompt            = "org.freedesktop.Secret.Prompt"
+
+	// ssPromptTimeout bounds how long an unlock dialog may stay open.
+	ssPromptTimeout = 2 * time.Minute
+)
+
+var errPromptDismissed = errors.New("secret service prompt dismissed")
+
+// ssSecret is the (oayays) struct of the Secret Service API.
+type ssSecret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// SecretService stores values in the desktop keyring, such as GNOME
+// Keyring or KWallet, through the freedesktop Secret Service D-Bus API.
+type SecretService struct {
+	conn    *dbus.Conn
+	prompts atomic.Bool
+}
+
+// systemStore returns the Secret Service when the session bus has one
+// running or able to start.
+func systemStore() Store {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil
+	}
+	var hasOwner bool
+	if err := conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, ssName).Store(&hasOwner); err != nil {
+		return nil
+	}
+	if !hasOwner {
+		var names []string
+		if err := conn.BusObject().Call("org.freedesktop.DBus.ListActivatableNames", 0).Store(&names); err != nil || !slices.Contains(names, ssName) {
+			return nil
+		}
+	}
+	return &SecretService{conn: conn}
+}
+
+func (s *SecretService) allowPrompt() {
+	s.prompts.Store(true)
+}
+
+func (s *SecretService) Name() string {
+	return "system keyring"
+}
+
+func (s *SecretService) service() dbus.BusObject {
+	return s.conn.Object(ssName, ssPath)
+}
+
+func (s *SecretService) attributes(key string) map[string]string {
+	return map[string]string{
+		"application": configs.AppName,
+		"key":         key,
+	}
+}
+
+// openSession starts a session with plain transfer, which is fine as the
+// session bus is private to the user.
+func (s *SecretService) openSession() (dbus.ObjectPath, error) {
+	var output dbus.Variant
+	var session dbus.ObjectPath
+	if err := s.service().Call(ssService+".OpenSession", 0, "plain", dbus.MakeVariant("")).Store(&output, &session); err != nil {
+		return "", err
+	}
+	return session, nil
+}
+
+func (s *SecretService) closeSession(session dbus.ObjectPath) {
+	s.conn.Object(ssName, session).Call(ssSession+".Close", 0)
+}
+
+// prompt shows a keyring dialog, such as the unlock password, and waits
+// for the user to finish it.
+func (s *SecretService) prompt(path dbus.ObjectPath) error {
+	if path == "/" || path == "" {
+		return nil
+	}
+	if !s.prompts.Load() {
+		return ErrLocked
+	}
+	if err := s.conn.AddMatchSignal(dbus.WithMatchObjectPath(path), dbus.WithMatchInterface(ssPrompt)); err != nil {
+		return err
+	}
+	defer s.conn.RemoveMatchSignal(dbus.WithMatchObjectPath(path), dbus.WithMatchInterface(ssPrompt))
+
+	signals := make(chan *dbus.Signal, 1)
+	s.conn.Signal(signals)
+	defer s.conn.RemoveSignal(signals)
+
+	if err := s.conn.Object(ssName, path).Call(ssPrompt+".Prompt", 0, "").Err; err != nil {
+		return err
+	}
+	timeout := time.After(ssPromptTimeout)
+	for {
+		select {
+		case signal := <-signals:
+			if signal.Path != path || signal.Name != ssPrompt+".Completed" {
+				continue
+			}
+			if len(signal.Body) > 0 {
+				if dismissed, ok := signal.Body[0].(bool); ok && dismissed {
+					return errPromptDismissed
+				}
+			}
+			return nil
+		case <-timeout:
+			return errPromptDismissed
+		}
+	}
+}
+
+func (s *SecretService) unlock(paths ...dbus.ObjectPath) error {
+	var unlocked []dbus.ObjectPath
+	var prompt dbus.ObjectPath
+	if err := s.service().Call(ssService+".Unlock", 0, paths).Store(&unlocked, &prompt); err != nil {
+		return err
+	}
+	return s.prompt(prompt)
+}
+
+func (s *SecretService) search(key string) ([]dbus.ObjectPath, error) {
+	var unlocked, locked []dbus.ObjectPath
+	if err := s.service().Call(ssService+".SearchItems", 0, s.attributes(key)).Store(&unlocked, &locked); err != nil {
+		return nil, err
+	}
+	if len(locked) > 0 {
+		if err := s.unlock(locked...); err != nil {
+			return nil, err
+		}
+	}
+	return append(unlocked, locked...), nil
+}
+
+func (s *SecretService) Get(key string) (string, error) {
+	items, err := s.search(key)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", ErrNotFound
+	}
+	session, err := s.openSession()
+	if err != nil {
+		return "", err
+	}
+	defer s.closeSession(session)
+
+	var secret ssSecret
+	if err := s.conn.Object(ssName, items[0]).Call(ssItem+".GetSecret", 0, session).Store(&secret); err != nil {
+		return "", err
+	}
+	return string(secret.Value), nil
+}
+
+func (s *SecretService) Set(key, value string) error {
+	if err := s.unlock(ssDefaultCollection); err != nil {
+		return err
+	}
+	session, err := s.openSession()
+	if err != nil {
+		return err
+	}
+	defer s.closeSession(session)
+
+	properties := map[string]dbus.Variant{
+		ssItem + ".Label":      dbus.MakeVariant(configs.AppName + " " + key),
+		ssItem + ".Attributes": dbus.MakeVariant(s.attributes(key)),
+	}
+	secret := ssSecret{
+		Session:     session,
+		Value:       []byte(value),
+		ContentType: "text/plain; charset=utf8",
+	}
+	var item, prompt dbus.ObjectPath
+	if err := s.conn.Object(ssName, ssDefaultCollection).Call(ssCollection+".CreateItem", 0, properties, secret, true).Store(&item, &prompt); err != nil {
+		return err
+	}
+	return s.prompt(prompt)
+}
+
+func (s *SecretService) Delete(key string) error {
+	items, err := s.search(key)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		var prompt dbus.ObjectPath
+		if err := s.conn.Object(ssName, item).Call(ssItem+".Delete", 0).Store(&prompt); err != nil {
+			return err
+		}
+		if err := s.prompt(prompt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package selfupdate replaces the launcher binary with a newer release.
+//
+// A verified binary is staged next to the running executable as ".new".
+// On the next start it is swapped in and the previous binary is kept as
+// ".old" so it can be rolled back to.
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"p86l/configs"
+	"p86l/internal/asset"
+	"p86l/internal/download"
+	"p86l/internal/release"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Newer reports whether latest is a higher version than current. Versions
+// are compared as dotted numbers with an optional "v" prefix, and a
+// pre-release suffix ranks below the plain version.
+func Newer(latest, current string) bool {
+	return compare(latest, current) > 0
+}
+
+func compare(a, b string) int {
+	// Build metadata does not take part in the order
+	a, _, _ = strings.Cut(a, "+")
+	b, _, _ = strings.Cut(b, "+")
+	a, aPre, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	b, bPre, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x > y {
+				return 1
+			}
+			return -1
+		}
+	}
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return comparePrerelease(aPre, bPre)
+}
+
+// comparePrerelease orders pre-release suffixes the semver way: dot
+// separated identifiers from left to right, numeric ones by value and below
+// alphanumeric ones, and a shorter list first when all else is equal.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < min(len(as), len(bs)); i++ {
+		x, xErr := strconv.ParseUint(as[i], 10, 64)
+		y, yErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case xErr == nil && yErr == nil:
+			if x != y {
+				if x > y {
+					return 1
+				}
+				return -1
+			}
+		case xErr == nil:
+			return -1
+		case yErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(as) > len(bs):
+		return 1
+	case len(as) < len(bs):
+		return -1
+	}
+	return 0
+}
+
+// IsVersion reports whether v looks like a release version rather than a
+// commit hash from an untagged build.
+func IsVersion(v string) bool {
+	v = strings.TrimPrefix(v, "v")
+	return v != "" && v[0] >= '0' && v[0] <= '9' && strings.Contains(v, ".")
+}
+
+// Check returns the latest stable launcher release of source when it is
+// newer than current. Untagged builds never update.
+func Check(ctx context.Context, source release.ReleaseSource, current string) (*release.Release, error) {
+	if !IsVersion(current) {
+		return nil, nil
+	}
+	latest, err := release.Resolve(ctx, source, release.ChannelStable, "")
+	if err != nil {
+		return nil, err
+	}
+	if !Newer(latest.Tag, current) {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// binaryAsset returns the launcher binary for this platform.
+func binaryAsset(assets []*release.Asset) (*release.Asset, error) {
+	var binaries []*release.Asset
+	for _, a := range assets {
+		if !release.IsChecksum(a.Name) {
+			binaries = append(binaries, a)
+		}
+	}
+	return asset.Select(configs.LauncherAssetRules, binaries, runtime.GOOS, runtime.GOARCH, nil)
+}
+
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func executable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+// Stage downloads the launcher binary of rel from source, verifies its
+// checksum and leaves it next to the running executable for Apply.
+func Stage(ctx context.Context, source release.ReleaseSource, rel *release.Release, progress download.Progress) error {
+	exe, err := executable()
+	if err != nil {
+		return err
+	}
+	assets, err := source.ListAssets(ctx, rel)
+	if err != nil {
+		return err
+	}
+	binary, err := binaryAsset(assets)
+	if err != nil {
+		return err
+	}
+	expected, err := release.Checksum(ctx, source, assets, binary)
+	if err != nil {
+		return err
+	}
+
+	staged := exe + ".new"
+	body, size, err := source.OpenAsset(ctx, binary)
+	if err != nil {
+		return err
+	}
+	err = download.Save(body, size, staged, progress)
+	body.Close()
+	if err != nil {
+		return err
+	}
+	actual, err := fileChecksum(staged)
+	if err != nil {
+		os.Remove(staged)
+		return err
+	}
+	if actual != expected {
+		os.Remove(staged)
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", binary.Name, expected, actual)
+	}
+	return os.Chmod(staged, 0755)
+}
+
+func Pending() bool {
+	exe, err := executable()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(exe + ".new")
+	return err == nil
+}
+
+func CanRollback() bool {
+	exe, err := executable()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(exe + ".old")
+	return err == nil
+}
+
+// Apply swaps a staged binary in, keeping the current one as ".old". It
+// reports whether a swap happened.
+func Apply() (bool, error) {
+	exe, err := executable()
+	if err != nil {
+		return false, err
+	}
+	// A binary replaced by Rollback could not be removed while it was
+	// running.
+	os.Remove(exe + ".bad")
+
+	staged := exe + ".new"
+	if _, err := os.Stat(staged); err != nil {
+		return false, nil
+	}
+	if err := swap(exe, staged, exe+".old"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Rollback puts the previous binary back in place. It takes effect on the
+// next start.
+func Rollback() error {
+	exe, err := executable()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(exe + ".new"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return swap(exe, exe+".old", exe+".bad")
+}
+
+// swap moves exe to keep and next to exe. Renaming a running executable is
+// allowed on every supported platform, unlike overwriting it.
+func swap(exe, next, keep string) error {
+	if err := os.Remove(keep); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(exe, keep); err != nil {
+		return err
+	}
+	if err := os.Rename(next, exe); err != nil {
+		os.Rename(keep, exe)
+		return err
+	}
+	return nil
+}
+
+// restartEnv marks a launcher started by Restart.
+const restartEnv = "P86L_RESTARTED"
+
+// Restarted reports whether Restart started this launcher, in which case
+// the previous one may not have exited yet. The mark is cleared so games
+// launched later do not inherit it.
+func Restarted() bool {
+	restarted := os.Getenv(restartEnv) != ""
+	os.Unsetenv(restartEnv)
+	return restarted
+}
+
+// Restart starts the launcher again with the same arguments, which applies
+// a staged update, and exits the current process. release, when not nil,
+// runs first to hand over what the new launcher needs, like the single
+// instance lock.
+func Restart(release func()) error {
+	exe, err := executable()
+	if err != nil {
+		return err
+	}
+	if release != nil {
+		release()
+	}
+	os.Setenv(restartEnv, "1")
+	return restart(exe)
+}
@@ -0,0 +1,116 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package single keeps one launcher running per user. Later launches
+// forward their arguments to the running one.
+package single
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned by Acquire when another process holds the lock.
+var ErrLocked = errors.New("another launcher is running")
+
+const (
+	forwardTimeout = 2 * time.Second
+	maxForwardSize = 64 << 10
+)
+
+// Lock is held by the running launcher for as long as it runs.
+type Lock struct {
+	listener net.Listener
+	release  func()
+	close    sync.Once
+	closeErr error
+}
+
+// Serve calls handle with the arguments of every forwarded launch. It
+// returns once the lock is closed.
+func (l *Lock) Serve(handle func(args []string)) {
+	for {
+		conn, err := l.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		conn.SetDeadline(time.Now().Add(forwardTimeout))
+		var args []string
+		err = json.NewDecoder(io.LimitReader(conn, maxForwardSize)).Decode(&args)
+		conn.Close()
+		if err == nil {
+			handle(args)
+		}
+	}
+}
+
+// Close releases the lock. Later calls do nothing.
+func (l *Lock) Close() error {
+	l.close.Do(func() {
+		l.closeErr = l.listener.Close()
+		if l.release != nil {
+			l.release()
+		}
+	})
+	return l.closeErr
+}
+
+// AcquireWait is Acquire retrying for up to wait while another launcher
+// holds the lock, for a launcher whose predecessor is still exiting.
+func AcquireWait(name string, wait time.Duration) (*Lock, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		lock, err := Acquire(name)
+		if !errors.Is(err, ErrLocked) || time.Now().After(deadline) {
+			return lock, err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// Forward sends args to the launcher holding the lock called name.
+func Forward(name string, args []string) error {
+	if args == nil {
+		args = []string{}
+	}
+	deadline := time.Now().Add(forwardTimeout)
+	for {
+		conn, err := dial(name, time.Until(deadline))
+		if err != nil {
+			// The running launcher may hold the lock but not listen yet.
+			if time.Now().Before(deadline) {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+		defer conn.Close()
+		conn.SetDeadline(deadline)
+		return json.NewEncoder(conn).Encode(args)
+	}
+}
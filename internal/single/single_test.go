@@ -0,0 +1,135 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package single
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testName returns a lock name of its own for t. Unix sockets are kept in
+// a temporary runtime folder.
+func testName(t *testing.T) string {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	return fmt.Sprintf("p86l-test-%d", time.Now().UnixNano())
+}
+
+func serve(lock *Lock) <-chan []string {
+	forwarded := make(chan []string, 4)
+	go lock.Serve(func(args []string) { forwarded <- args })
+	return forwarded
+}
+
+func receive(t *testing.T, forwarded <-chan []string) []string {
+	t.Helper()
+	select {
+	case args := <-forwarded:
+		return args
+	case <-time.After(5 * time.Second):
+		t.Fatal("nothing forwarded")
+		return nil
+	}
+}
+
+func TestAcquireForwards(t *testing.T) {
+	name := testName(t)
+	lock, err := Acquire(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Close()
+	forwarded := serve(lock)
+
+	// A second launcher cannot take the lock and hands its arguments over.
+	if second, err := Acquire(name); !errors.Is(err, ErrLocked) {
+		if second != nil {
+			second.Close()
+		}
+		t.Fatalf("second Acquire() error = %v, want %v", err, ErrLocked)
+	}
+	for _, args := range [][]string{
+		{"p86l://launch/default"},
+		{"install", "-dir", "/games/two words", "-instance", "ünïcode"},
+		nil,
+	} {
+		if err := Forward(name, args); err != nil {
+			t.Fatal(err)
+		}
+		want := args
+		if want == nil {
+			want = []string{}
+		}
+		if got := receive(t, forwarded); !slices.Equal(got, want) {
+			t.Errorf("forwarded %q, want %q", got, want)
+		}
+	}
+}
+
+func TestCloseReleases(t *testing.T) {
+	name := testName(t)
+	lock, err := Acquire(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.Close(); err != nil {
+		t.Errorf("second Close() = %v", err)
+	}
+
+	// The next launcher takes over, socket left behind or not.
+	lock, err = Acquire(name)
+	if err != nil {
+		t.Fatalf("Acquire() after Close = %v", err)
+	}
+	defer lock.Close()
+	forwarded := serve(lock)
+	if err := Forward(name, []string{"-list"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := receive(t, forwarded); !slices.Equal(got, []string{"-list"}) {
+		t.Errorf("forwarded %q, want [-list]", got)
+	}
+}
+
+func TestAcquireWait(t *testing.T) {
+	name := testName(t)
+	lock, err := Acquire(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AcquireWait(name, 200*time.Millisecond); !errors.Is(err, ErrLocked) {
+		t.Fatalf("AcquireWait() error = %v, want %v", err, ErrLocked)
+	}
+
+	// A restarting launcher waits for the old one to exit.
+	time.AfterFunc(200*time.Millisecond, func() { lock.Close() })
+	next, err := AcquireWait(name, 5*time.Second)
+	if err != nil {
+		t.Fatalf("AcquireWait() = %v", err)
+	}
+	next.Close()
+}
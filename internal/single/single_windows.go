@@ -0,0 +1,58 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package single
+
+import (
+	"errors"
+	"net"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/Microsoft/go-winio"
+	"golang.org/x/sys/windows"
+)
+
+func pipePath(name string) string {
+	path := `\\.\pipe\` + name
+	if current, err := user.Current(); err == nil {
+		path += "-" + strings.NewReplacer(`\`, "-", "/", "-").Replace(current.Username)
+	}
+	return path
+}
+
+// Acquire takes the lock called name. Only the first process can create the
+// named pipe, so the pipe itself is the lock.
+func Acquire(name string) (*Lock, error) {
+	listener, err := winio.ListenPipe(pipePath(name), nil)
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) || errors.Is(err, windows.ERROR_PIPE_BUSY) {
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Lock{listener: listener}, nil
+}
+
+func dial(name string, timeout time.Duration) (net.Conn, error) {
+	return winio.DialPipe(pipePath(name), &timeout)
+}
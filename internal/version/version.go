@@ -0,0 +1,120 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package version describes the running launcher build.
+//
+// Release builds stamp the variables below with ldflags, see Taskfile.yml.
+// Anything left empty is filled in from the module build info, which also
+// covers plain "go build" and "go run".
+package version
+
+import (
+	"fmt"
+	"p86l/configs"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+var (
+	Version string
+	Commit  string
+	Date    string
+)
+
+type Info struct {
+	Version   string
+	Commit    string
+	Date      string
+	GoVersion string
+	Dirty     bool
+}
+
+var (
+	info     Info
+	infoOnce sync.Once
+)
+
+func Get() Info {
+	infoOnce.Do(func() {
+		info = Info{
+			Version:   Version,
+			Commit:    Commit,
+			Date:      Date,
+			GoVersion: runtime.Version(),
+		}
+		buildInfo, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		if info.Version == "" && buildInfo.Main.Version != "(devel)" {
+			info.Version = buildInfo.Main.Version
+		}
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = setting.Value
+				}
+			case "vcs.modified":
+				info.Dirty = setting.Value == "true"
+			}
+		}
+		if len(info.Commit) > 7 {
+			info.Commit = info.Commit[:7]
+		}
+	})
+	return info
+}
+
+// Semver returns the version without the "v" prefix, or "dev" for
+// untagged builds.
+func (i Info) Semver() string {
+	if i.Version == "" {
+		return "dev"
+	}
+	return strings.TrimPrefix(i.Version, "v")
+}
+
+func (i Info) String() string {
+	s := fmt.Sprintf("%s %s", configs.AppName, i.Semver())
+	if i.Commit != "" {
+		s += " (" + i.Commit
+		if i.Dirty {
+			s += "-dirty"
+		}
+		s += ")"
+	}
+	if i.Date != "" {
+		s += " built " + i.Date
+	}
+	return s + " " + i.GoVersion + " " + runtime.GOOS + "/" + runtime.GOARCH
+}
+
+func UserAgent() string {
+	i := Get()
+	return fmt.Sprintf("%s/%s (%s; %s/%s)", configs.AppName, i.Semver(), i.GoVersion, runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,164 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"fmt"
+	"image"
+	ESApp "p86l/internal/app"
+	"p86l/internal/data"
+	"p86l/internal/deeplink"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+	"github.com/rs/zerolog/log"
+)
+
+// LinkPopup asks before a deep link launches a game or changes an
+// instance, as any web page can open one.
+type LinkPopup struct {
+	guigui.DefaultWidget
+
+	popup         basicwidget.Popup
+	titleText     basicwidget.Text
+	messageText   basicwidget.Text
+	confirmButton basicwidget.TextButton
+	cancelButton  basicwidget.TextButton
+
+	link             *deeplink.Link
+	onChannelChanged func(name string)
+}
+
+func (l *LinkPopup) Open(link *deeplink.Link) {
+	l.link = link
+	l.popup.Open()
+}
+
+// SetOnChannelChanged sets the function called after a link switched the
+// channel of an instance.
+func (l *LinkPopup) SetOnChannelChanged(f func(name string)) {
+	l.onChannelChanged = f
+}
+
+func (l *LinkPopup) confirm() {
+	link := l.link
+	if link == nil {
+		return
+	}
+	switch link.Action {
+	case deeplink.ActionLaunch:
+		go func() {
+			if err := app.LaunchWith(link.Instance, ESApp.LaunchOptions{NoHooks: true}); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+		}()
+	case deeplink.ActionInstall:
+		if err := app.Data.UpdateInstance(app.Debug, link.Instance, func(instance *data.Instance) {
+			instance.Channel = link.Channel
+			if link.Tag != "" {
+				instance.PinnedTag = link.Tag
+			}
+		}); err.Err != nil {
+			app.Debug.SetToast(err)
+			return
+		}
+		log.Info().Str("Instance", link.Instance).Str("Channel", string(link.Channel)).Msg("Channel changed by link")
+		if l.onChannelChanged != nil {
+			l.onChannelChanged(link.Instance)
+		}
+	}
+}
+
+func (l *LinkPopup) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppender) {
+	u := float64(basicwidget.UnitSize(context))
+	contentWidth := int(16 * u)
+
+	l.titleText.SetText("Open link?")
+	l.titleText.SetBold(true)
+
+	var message string
+	if link := l.link; link != nil {
+		switch link.Action {
+		case deeplink.ActionLaunch:
+			message = fmt.Sprintf("A link asks to launch %s. Launch profile hooks do not run for links.", link.Instance)
+			l.confirmButton.SetText("Launch")
+		case deeplink.ActionInstall:
+			channel := string(link.Channel)
+			if link.Tag != "" {
+				channel = fmt.Sprintf("%s channel at %s", channel, link.Tag)
+			} else {
+				channel += " channel"
+			}
+			message = fmt.Sprintf("A link asks to switch %s to the %s.", link.Instance, channel)
+			l.confirmButton.SetText("Switch")
+		}
+	}
+	l.messageText.SetMultiline(true)
+	l.messageText.SetText(WrapText(context, message, contentWidth-int(1*u)))
+
+	l.confirmButton.SetOnUp(func() {
+		l.popup.Close()
+		l.confirm()
+	})
+	l.cancelButton.SetText("Cancel")
+	l.cancelButton.SetOnUp(func() {
+		l.popup.Close()
+	})
+
+	l.messageText.SetWidth(contentWidth - int(1*u))
+	_, textHeight := l.messageText.Size(context)
+	contentHeight := textHeight + int(4*u)
+	guigui.SetPosition(&l.popup, image.Pt(0, 0))
+	bounds := guigui.Bounds(&l.popup)
+	contentPosition := image.Point{
+		X: bounds.Min.X + (bounds.Dx()-contentWidth)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-contentHeight)/2,
+	}
+	contentBounds := image.Rectangle{
+		Min: contentPosition,
+		Max: contentPosition.Add(image.Pt(contentWidth, contentHeight)),
+	}
+	l.popup.SetContent(func(context *guigui.Context, appender *basicwidget.ContainerChildWidgetAppender) {
+		pt := contentBounds.Min.Add(image.Pt(int(0.5*u), int(0.5*u)))
+		guigui.SetPosition(&l.titleText, pt)
+		appender.AppendChildWidget(&l.titleText)
+
+		pt.Y += int(1 * u)
+		guigui.SetPosition(&l.messageText, pt)
+		appender.AppendChildWidget(&l.messageText)
+
+		w, h := l.confirmButton.Size(context)
+		pt = contentBounds.Max.Add(image.Pt(-int(0.5*u)-w, -int(0.5*u)-h))
+		guigui.SetPosition(&l.confirmButton, pt)
+		appender.AppendChildWidget(&l.confirmButton)
+
+		w, _ = l.cancelButton.Size(context)
+		pt.X -= w + int(0.5*u)
+		guigui.SetPosition(&l.cancelButton, pt)
+		appender.AppendChildWidget(&l.cancelButton)
+	})
+	l.popup.SetContentBounds(contentBounds)
+	l.popup.SetBackgroundBlurred(true)
+	l.popup.SetCloseByClickingOutside(false)
+
+	appender.AppendChildWidget(&l.popup)
+}
@@ -0,0 +1,222 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"p86l/configs"
+	ESApp "p86l/internal/app"
+	"p86l/internal/debug"
+	"p86l/internal/news"
+	"p86l/internal/widget"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+	"github.com/rs/zerolog/log"
+)
+
+const newsTitleLength = 48
+
+type newsRow struct {
+	item      news.Item
+	thumbnail basicwidget.Image
+	button    basicwidget.TextButton
+}
+
+// News lists the latest items of the news feed on Home.
+type News struct {
+	guigui.DefaultWidget
+
+	vLayout        widget.VerticalLayout
+	titleText      basicwidget.Text
+	form           widget.Form
+	markReadButton basicwidget.TextButton
+	rows           []*newsRow
+
+	// loaded holds the decoded thumbnails by reference, nil for the ones
+	// that failed. They become ebiten images in Layout.
+	loaded     sync.Map
+	requested  map[string]bool
+	thumbnails map[string]*ebiten.Image
+}
+
+// SetItems shows the first configs.NewsShown items.
+func (n *News) SetItems(context *guigui.Context, items []news.Item) {
+	items = items[:min(len(items), configs.NewsShown)]
+	if len(n.rows) != len(items) {
+		n.rows = make([]*newsRow, len(items))
+		for i := range n.rows {
+			n.rows[i] = &newsRow{}
+		}
+	}
+	formItems := make([]*widget.FormItem, 0, len(items))
+	for i, item := range items {
+		row := n.rows[i]
+		row.item = item
+		formItem := &widget.FormItem{SecondaryWidget: &row.button}
+		if img := n.thumbnail(item); img != nil {
+			u := float64(basicwidget.UnitSize(context))
+			height := int(1.5 * u)
+			width := min(height*img.Bounds().Dx()/max(img.Bounds().Dy(), 1), int(3*u))
+			row.thumbnail.SetImage(img)
+			row.thumbnail.SetSize(context, width, height)
+			formItem.PrimaryWidget = &row.thumbnail
+		}
+		formItems = append(formItems, formItem)
+	}
+	n.form.SetItems(formItems)
+
+	layoutItems := []*widget.LayoutItem{{Widget: &n.titleText}, {Widget: &n.form}}
+	if n.unread() > 0 {
+		layoutItems = append(layoutItems, &widget.LayoutItem{Widget: &n.markReadButton})
+	}
+	n.vLayout.SetItems(layoutItems)
+}
+
+// thumbnail returns the thumbnail of item once it is loaded, and starts
+// loading it otherwise.
+func (n *News) thumbnail(item news.Item) *ebiten.Image {
+	ref := item.Thumbnail
+	if ref == "" {
+		return nil
+	}
+	if img, ok := n.thumbnails[ref]; ok {
+		return img
+	}
+	if loaded, ok := n.loaded.Load(ref); ok {
+		var img *ebiten.Image
+		if loaded != nil {
+			img = ebiten.NewImageFromImage(loaded.(image.Image))
+		}
+		if n.thumbnails == nil {
+			n.thumbnails = map[string]*ebiten.Image{}
+		}
+		n.thumbnails[ref] = img
+		return img
+	}
+	if n.requested[ref] {
+		return nil
+	}
+	if n.requested == nil {
+		n.requested = map[string]bool{}
+	}
+	n.requested[ref] = true
+	go func() {
+		img, err := app.NewsThumbnail(context.Background(), item)
+		if err != nil {
+			log.Warn().Err(err).Str("Thumbnail", ref).Msg("News thumbnail")
+			n.loaded.Store(ref, nil)
+			return
+		}
+		n.loaded.Store(ref, img)
+	}()
+	return nil
+}
+
+func (n *News) unread() int {
+	var count int
+	for _, row := range n.rows {
+		if !app.IsNewsRead(row.item.ID) {
+			count++
+		}
+	}
+	return count
+}
+
+func (n *News) open(item news.Item) {
+	if err := ESApp.ValidLink(configs.Link{Label: item.Title, URL: item.URL}); err != nil {
+		app.Debug.SetToast(app.Debug.New(err, debug.AppError, debug.ErrBrowserOpen))
+		return
+	}
+	openURL(item.URL)
+	if err := app.SetNewsRead(true, item.ID); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+}
+
+func (n *News) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppender) {
+	u := float64(basicwidget.UnitSize(context))
+	w, _ := n.Size(context)
+
+	n.titleText.SetBold(true)
+	if unread := n.unread(); unread > 0 {
+		n.titleText.SetText(fmt.Sprintf("News (%d unread)", unread))
+	} else {
+		n.titleText.SetText("News")
+	}
+
+	for _, row := range n.rows {
+		title := []rune(row.item.Title)
+		if len(title) > newsTitleLength {
+			title = append(title[:newsTitleLength-1], '…')
+		}
+		text := string(title)
+		if !row.item.Published.IsZero() {
+			text = fmt.Sprintf("%s - %s", text, row.item.Published.Local().Format("Jan 2, 2006"))
+		}
+		if !app.IsNewsRead(row.item.ID) {
+			text = "● " + text
+		}
+		row.button.SetText(text)
+		buttonWidth := w - int(2*u)
+		if _, ok := n.thumbnails[row.item.Thumbnail]; ok && row.item.Thumbnail != "" {
+			tw, _ := row.thumbnail.Size(context)
+			buttonWidth -= tw + int(0.5*u)
+		}
+		row.button.SetWidth(buttonWidth)
+		row.button.SetOnDown(func() {
+			n.open(row.item)
+		})
+	}
+
+	n.markReadButton.SetText("Mark all read")
+	n.markReadButton.SetOnDown(func() {
+		ids := make([]string, 0, len(n.rows))
+		for _, row := range n.rows {
+			ids = append(ids, row.item.ID)
+		}
+		if err := app.SetNewsRead(true, ids...); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+
+	n.form.SetWidth(context, w-int(1*u))
+
+	n.vLayout.SetHorizontalAlign(widget.HorizontalAlignStart)
+	n.vLayout.SetBackground(true)
+	n.vLayout.SetLineBreak(false)
+	n.vLayout.SetBorder(true)
+	guigui.SetPosition(&n.vLayout, guigui.Position(n))
+	appender.AppendChildWidget(&n.vLayout)
+}
+
+func (n *News) SetWidth(context *guigui.Context, width int) {
+	n.vLayout.SetWidth(context, width)
+}
+
+func (n *News) Size(context *guigui.Context) (int, int) {
+	return n.vLayout.Size(context)
+}
@@ -0,0 +1,275 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86 for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"fmt"
+	"p86l/internal/data"
+	"p86l/internal/debug"
+	"p86l/internal/runner"
+	"p86l/internal/widget"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/guigui"
+	"github.com/hajimehoshi/guigui/basicwidget"
+)
+
+// profileNames are the items of a launch profile dropdown: none, then the
+// profiles of instance.
+func profileNames(instance *data.Instance) []string {
+	names := []string{"None"}
+	for _, profile := range instance.Profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+// selectProfile selects the profile at index of a launch profile dropdown.
+func selectProfile(instance *data.Instance, index int) {
+	var name string
+	if index > 0 && index <= len(instance.Profiles) {
+		name = instance.Profiles[index-1].Name
+	}
+	if name == instance.Profile {
+		return
+	}
+	if err := app.SelectProfile(instance.Name, name); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+}
+
+// commandPreview is what a command line preview was built from.
+type commandPreview struct {
+	instance string
+	tag      string
+	runner   *runner.Runner
+	profile  *data.LaunchProfile
+}
+
+// launchProfiles edits the launch profiles of an instance on the Instances
+// page.
+type launchProfiles struct {
+	profileForm         widget.Form
+	profileText         basicwidget.Text
+	profileDropdownList basicwidget.DropdownList
+	newForm             widget.Form
+	newField            basicwidget.TextField
+	addButton           basicwidget.TextButton
+	argsForm            widget.Form
+	argsText            basicwidget.Text
+	argsField           basicwidget.TextField
+	envForm             widget.Form
+	envText             basicwidget.Text
+	envField            basicwidget.TextField
+	dirForm             widget.Form
+	dirText             basicwidget.Text
+	dirField            basicwidget.TextField
+	preLaunchForm       widget.Form
+	preLaunchText       basicwidget.Text
+	preLaunchField      basicwidget.TextField
+	postExitForm        widget.Form
+	postExitText        basicwidget.Text
+	postExitField       basicwidget.TextField
+	deleteButton        basicwidget.TextButton
+	previewText         basicwidget.Text
+	launchButton        basicwidget.TextButton
+
+	synced    *data.LaunchProfile
+	previewed commandPreview
+	preview   string
+}
+
+// profile returns a copy of the selected profile to change.
+func (l *launchProfiles) profile(instance *data.Instance) *data.LaunchProfile {
+	p := *instance.LaunchProfile("")
+	return &p
+}
+
+func (l *launchProfiles) save(instance *data.Instance, profile *data.LaunchProfile) {
+	if err := app.SaveProfile(instance.Name, profile); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+}
+
+// items lays the profile widgets of instance out in width and returns them.
+func (l *launchProfiles) items(context *guigui.Context, instance *data.Instance, width int) []*widget.LayoutItem {
+	selected := instance.LaunchProfile("")
+	names := profileNames(instance)
+	l.profileDropdownList.SetItemsByStrings(names)
+	l.profileDropdownList.SetSelectedItemIndex(max(0, slices.Index(names[1:], instance.Profile)+1))
+
+	// Copy the stored profile into the fields whenever it changes.
+	if !reflect.DeepEqual(selected, l.synced) {
+		l.synced = selected
+		l.argsField.SetText("")
+		l.envField.SetText("")
+		l.dirField.SetText("")
+		l.preLaunchField.SetText("")
+		l.postExitField.SetText("")
+		if selected != nil {
+			l.argsField.SetText(runner.JoinArgs(selected.Args))
+			l.envField.SetText(runner.FormatEnv(selected.Env))
+			l.dirField.SetText(selected.Dir)
+			l.preLaunchField.SetText(selected.PreLaunch)
+			l.postExitField.SetText(selected.PostExit)
+		}
+	}
+
+	l.profileDropdownList.SetOnValueChanged(func(index int) {
+		selectProfile(instance, index)
+	})
+	l.addButton.SetOnDown(func() {
+		name := strings.TrimSpace(l.newField.Text())
+		if instance.LaunchProfile(name) != nil {
+			app.Debug.SetToast(app.Debug.New(fmt.Errorf("launch profile %q already exists", name), debug.DataError, debug.ErrInstanceInvalid))
+			return
+		}
+		l.save(instance, &data.LaunchProfile{Name: name})
+		if err := app.SelectProfile(instance.Name, name); err.Err != nil {
+			app.Debug.SetToast(err)
+			return
+		}
+		l.newField.SetText("")
+	})
+	l.argsField.SetOnEnterPressed(func(text string) {
+		args, err := runner.SplitArgs(text)
+		if err != nil {
+			app.Debug.SetToast(app.Debug.New(err, debug.DataError, debug.ErrInstanceInvalid))
+			return
+		}
+		p := l.profile(instance)
+		p.Args = args
+		l.save(instance, p)
+	})
+	l.envField.SetOnEnterPressed(func(text string) {
+		env, err := runner.ParseEnv(text)
+		if err != nil {
+			app.Debug.SetToast(app.Debug.New(err, debug.DataError, debug.ErrInstanceInvalid))
+			return
+		}
+		p := l.profile(instance)
+		p.Env = env
+		l.save(instance, p)
+	})
+	l.dirField.SetOnEnterPressed(func(text string) {
+		p := l.profile(instance)
+		p.Dir = strings.TrimSpace(text)
+		if p.Dir != "" {
+			p.Dir = filepath.Clean(p.Dir)
+		}
+		l.save(instance, p)
+	})
+	l.preLaunchField.SetOnEnterPressed(func(text string) {
+		p := l.profile(instance)
+		p.PreLaunch = strings.TrimSpace(text)
+		l.save(instance, p)
+	})
+	l.postExitField.SetOnEnterPressed(func(text string) {
+		p := l.profile(instance)
+		p.PostExit = strings.TrimSpace(text)
+		l.save(instance, p)
+	})
+	l.deleteButton.SetOnDown(func() {
+		if err := app.DeleteProfile(instance.Name, instance.Profile); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+	l.launchButton.SetOnDown(func() {
+		go func() {
+			if err := app.Launch(instance.Name); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+		}()
+	})
+
+	u := float64(basicwidget.UnitSize(context))
+	l.profileText.SetText("Launch profile")
+	l.addButton.SetText("Add profile")
+	l.argsText.SetText("Arguments")
+	l.envText.SetText("Environment (KEY=value ...)")
+	l.dirText.SetText("Working directory")
+	l.preLaunchText.SetText("Pre-launch command")
+	l.postExitText.SetText("Post-exit command")
+	l.deleteButton.SetText("Delete profile")
+	l.launchButton.SetText("Launch")
+	for _, field := range []*basicwidget.TextField{&l.newField, &l.argsField, &l.envField, &l.dirField, &l.preLaunchField, &l.postExitField} {
+		field.SetSize(context, int(8*u), int(u))
+	}
+	forms := []*widget.Form{&l.profileForm, &l.newForm, &l.argsForm, &l.envForm, &l.dirForm, &l.preLaunchForm, &l.postExitForm}
+	for _, form := range forms {
+		form.SetWidth(context, width)
+	}
+	l.profileForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &l.profileText, SecondaryWidget: &l.profileDropdownList},
+	})
+	l.newForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &l.newField, SecondaryWidget: &l.addButton},
+	})
+	l.argsForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &l.argsText, SecondaryWidget: &l.argsField},
+	})
+	l.envForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &l.envText, SecondaryWidget: &l.envField},
+	})
+	l.dirForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &l.dirText, SecondaryWidget: &l.dirField},
+	})
+	l.preLaunchForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &l.preLaunchText, SecondaryWidget: &l.preLaunchField},
+	})
+	l.postExitForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &l.postExitText, SecondaryWidget: &l.postExitField},
+	})
+
+	items := []*widget.LayoutItem{{Widget: &l.profileForm}, {Widget: &l.newForm}}
+	if selected != nil {
+		items = append(items,
+			&widget.LayoutItem{Widget: &l.argsForm},
+			&widget.LayoutItem{Widget: &l.envForm},
+			&widget.LayoutItem{Widget: &l.dirForm},
+			&widget.LayoutItem{Widget: &l.preLaunchForm},
+			&widget.LayoutItem{Widget: &l.postExitForm},
+			&widget.LayoutItem{Widget: &l.deleteButton},
+		)
+	}
+	if !app.IsInstalled(instance.Name) {
+		return items
+	}
+
+	// Building the command looks the executable up, so only do it again
+	// when something it depends on changed.
+	previewed := commandPreview{instance: instance.Name, tag: instance.Tag, runner: instance.Runner, profile: selected}
+	if !reflect.DeepEqual(previewed, l.previewed) {
+		l.previewed = previewed
+		line, err := app.CommandLine(instance.Name, "")
+		if err.Err != nil {
+			line = err.Err.Error()
+		}
+		l.preview = line
+	}
+	l.previewText.SetWidth(width)
+	l.previewText.SetText(WrapText(context, l.preview, width))
+	return append(items, &widget.LayoutItem{Widget: &l.previewText}, &widget.LayoutItem{Widget: &l.launchButton})
+}
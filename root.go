@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"image"
 	"p86l/internal/debug"
+	"p86l/internal/deeplink"
 	"p86l/internal/widget"
 	"sync"
 	"time"
@@ -38,28 +39,36 @@ import (
 type Root struct {
 	guigui.RootWidget
 
-	lastCheckInternet    time.Time
-	checkInternetTimeout time.Duration
+	lastCheckData    time.Time
+	checkDataTimeout time.Duration
 
 	sidebar   Sidebar
 	home      Home
 	settings  Settings
+	instances Instances
 	changelog Changelog
 	about     About
 
-	toast widget.Toast
+	toast             widget.Toast
+	dependenciesPopup DependenciesPopup
+	linkPopup         LinkPopup
 
 	popup            basicwidget.Popup
 	popupPanel       basicwidget.ScrollablePanel
 	popupText        basicwidget.Text
 	popupCloseButton basicwidget.TextButton
 
-	initOnce sync.Once
-	err      *debug.Error
+	initOnce   sync.Once
+	unlockOnce sync.Once
+	err        *debug.Error
+	// raised is set for the frame the window floats to come to the front.
+	raised bool
+	// page is the sidebar item shown last frame.
+	page string
 }
 
 func (r *Root) once() {
-	r.checkInternetTimeout = time.Second
+	r.checkDataTimeout = time.Second
 	// if err := Run(); err.Err != nil {
 	// 	r.err = err
 	// 	return
@@ -97,6 +106,7 @@ func (r *Root) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 
 	guigui.SetPosition(&r.home, p)
 	guigui.SetPosition(&r.settings, p)
+	guigui.SetPosition(&r.instances, p)
 	guigui.SetPosition(&r.changelog, p)
 	guigui.SetPosition(&r.about, p)
 	guigui.SetPosition(&r.toast, p.Add(image.Pt(0, h-int(1.5*u))))
@@ -106,6 +116,8 @@ func (r *Root) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 		appender.AppendChildWidget(&r.home)
 	case "settings":
 		appender.AppendChildWidget(&r.settings)
+	case "instances":
+		appender.AppendChildWidget(&r.instances)
 	case "changelog":
 		appender.AppendChildWidget(&r.changelog)
 	case "about":
@@ -119,6 +131,9 @@ func (r *Root) Layout(context *guigui.Context, appender *guigui.ChildWidgetAppen
 		})
 		appender.AppendChildWidget(&r.toast)
 	}
+	appender.AppendChildWidget(&r.dependenciesPopup)
+	r.linkPopup.SetOnChannelChanged(r.instances.refreshReleases)
+	appender.AppendChildWidget(&r.linkPopup)
 
 	// if len(app.Errs) != 0 {
 	// 	r.popup.Open()
@@ -191,11 +206,47 @@ func (r *Root) Update(context *guigui.Context) error {
 		return err.Err
 	}
 
+	// A locked keyring is only asked to unlock once the window is up.
+	r.unlockOnce.Do(func() {
+		go func() {
+			if err := app.UnlockToken(); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+		}()
+	})
+
+	// Ebiten cannot focus the window, so floating it for a frame brings
+	// it above the others.
+	if r.raised {
+		ebiten.SetWindowFloating(false)
+		r.raised = false
+	}
+	if raiseWindow.Swap(false) {
+		if ebiten.IsWindowMinimized() {
+			ebiten.RestoreWindow()
+		}
+		ebiten.SetWindowFloating(true)
+		r.raised = true
+	}
+
+	if link := pendingLink.Swap(nil); link != nil {
+		r.route(link)
+	}
+	if tag := r.sidebar.SelectedItemTag(); tag != r.page {
+		r.page = tag
+		if tag == "settings" {
+			r.settings.RefreshUsage()
+		}
+	}
+	if missing := app.TakeMissingDependencies(); missing != nil {
+		r.dependenciesPopup.Open(missing)
+	}
+
 	now := time.Now()
 
-	if now.Sub(r.lastCheckInternet) > r.checkInternetTimeout {
+	if now.Sub(r.lastCheckData) > r.checkDataTimeout {
 		if !app.FS.IsDir() {
-			err := app.Data.HandleDataReset(app.Debug)
+			err := app.ResetData()
 			if err.Err != nil {
 				AppErr = err
 				return err.Err
@@ -203,8 +254,7 @@ func (r *Root) Update(context *guigui.Context) error {
 			log.Info().Msg("HandleDataReset")
 		}
 
-		go app.UpdateInternet()
-		r.lastCheckInternet = now
+		r.lastCheckData = now
 
 		//app.PopupError(errors.New("YES"))
 		//app.PopupError(errors.New("YEAKPWOKDPWKDPOWKDPOWKDPOWKDPOKWDOWKDPOWKDOWKDPWKDOPWKDPOWKDOPWKDPOWKDPOWKDPOWKDPOWKDPOWDKPWOKDOPWDKPWODKWPODKPOWDKS"))
@@ -215,6 +265,38 @@ func (r *Root) Update(context *guigui.Context) error {
 	return nil
 }
 
+// route opens the page a deep link points at. Launching and switching
+// channels wait for the user to confirm.
+func (r *Root) route(link *deeplink.Link) {
+	if link.Action == deeplink.ActionChangelog {
+		r.changelog.ShowRelease(link.Tag)
+		r.sidebar.SelectItemTag("changelog")
+		return
+	}
+
+	r.sidebar.SelectItemTag("instances")
+	instance := app.Data.Instance(link.Instance)
+	if instance == nil {
+		app.Debug.SetToast(app.Debug.New(fmt.Errorf("instance %s not found", link.Instance), debug.InstallError, debug.ErrInstanceNotFound))
+		return
+	}
+	r.instances.Select(instance.Name)
+
+	switch link.Action {
+	case deeplink.ActionLaunch:
+		if !app.IsInstalled(instance.Name) {
+			app.Debug.SetToast(app.Debug.New(fmt.Errorf("instance %s is not installed", instance.Name), debug.LaunchError, debug.ErrGameNotFound))
+			return
+		}
+		r.linkPopup.Open(link)
+	case deeplink.ActionInstall:
+		if link.Channel == "" || (link.Channel == instance.Channel && (link.Tag == "" || link.Tag == instance.PinnedTag)) {
+			return
+		}
+		r.linkPopup.Open(link)
+	}
+}
+
 func (r *Root) Draw(context *guigui.Context, dst *ebiten.Image) {
 	basicwidget.FillBackground(dst, context)
 }
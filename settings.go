@@ -22,11 +22,18 @@
 package p86l
 
 import (
+	"fmt"
 	"image"
 	"p86l/configs"
+	"p86l/internal/data"
 	"p86l/internal/debug"
+	"p86l/internal/file"
+	"p86l/internal/selfupdate"
 	"p86l/internal/widget"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/guigui"
 	"github.com/hajimehoshi/guigui/basicwidget"
@@ -48,6 +55,52 @@ type Settings struct {
 	clearCacheButton     basicwidget.TextButton
 	clearDataButton      basicwidget.TextButton
 	deleteFilesButton    basicwidget.TextButton
+	usageText            basicwidget.Text
+	launcherUpdateButton basicwidget.TextButton
+	networkForm          widget.Form
+	networkText          basicwidget.Text
+	networkStatusText    basicwidget.Text
+	probeForm            widget.Form
+	probeText            basicwidget.Text
+	probeField           basicwidget.TextField
+	proxyForm            widget.Form
+	proxyText            basicwidget.Text
+	proxyField           basicwidget.TextField
+	sourceForm           widget.Form
+	sourceText           basicwidget.Text
+	sourceField          basicwidget.TextField
+	mirrorsForm          widget.Form
+	mirrorsText          basicwidget.Text
+	mirrorsField         basicwidget.TextField
+	limitForm            widget.Form
+	limitText            basicwidget.Text
+	limitField           basicwidget.TextField
+	connectionsForm      widget.Form
+	connectionsText      basicwidget.Text
+	connectionsField     basicwidget.TextField
+	scheduleForm         widget.Form
+	scheduleText         basicwidget.Text
+	scheduleField        basicwidget.TextField
+	pauseButton          basicwidget.TextButton
+	queueText            basicwidget.Text
+	newsForm             widget.Form
+	newsText             basicwidget.Text
+	newsField            basicwidget.TextField
+	tokenForm            widget.Form
+	tokenText            basicwidget.Text
+	tokenField           basicwidget.TextField
+	rateLimitText        basicwidget.Text
+	removeTokenButton    basicwidget.TextButton
+	rollbackButton       basicwidget.TextButton
+	deleteFilesPopup     DeleteFilesPopup
+
+	updatingLauncher atomic.Bool
+	checkingToken    atomic.Bool
+
+	usage        atomic.Pointer[file.Usage]
+	usageRunning atomic.Bool
+	usageStale   atomic.Bool
+	filesVersion uint64
 
 	initOnce sync.Once
 	err      *debug.Error
@@ -61,6 +114,22 @@ func (s *Settings) Layout(context *guigui.Context, appender *guigui.ChildWidgetA
 		}
 
 		s.appScaleDropdownList.SetSelectedItemIndex(app.Data.AppScale)
+		settings := app.Data.Network()
+		s.probeField.SetText(settings.ProbeURL)
+		s.proxyField.SetText(settings.Proxy)
+		s.sourceField.SetText(settings.ReleaseSource)
+		s.mirrorsField.SetText(strings.Join(settings.Mirrors, ", "))
+		s.newsField.SetText(app.NewsSettings().FeedURL)
+		downloads := app.DownloadSettings()
+		if downloads.Limit > 0 {
+			s.limitField.SetText(strconv.FormatInt(downloads.Limit, 10))
+		}
+		if downloads.Connections > 0 {
+			s.connectionsField.SetText(strconv.Itoa(downloads.Connections))
+		}
+		if downloads.ScheduleStart != "" {
+			s.scheduleField.SetText(downloads.ScheduleStart + "-" + downloads.ScheduleEnd)
+		}
 	})
 
 	s.colorModeToggle.SetOnValueChanged(func(value bool) {
@@ -89,6 +158,109 @@ func (s *Settings) Layout(context *guigui.Context, appender *guigui.ChildWidgetA
 		}
 	})
 
+	s.probeField.SetOnEnterPressed(func(text string) {
+		settings := s.network()
+		settings.ProbeURL = strings.TrimSpace(text)
+		s.saveNetwork(settings)
+	})
+
+	s.proxyField.SetOnEnterPressed(func(text string) {
+		settings := s.network()
+		settings.Proxy = strings.TrimSpace(text)
+		s.saveNetwork(settings)
+	})
+
+	s.sourceField.SetOnEnterPressed(func(text string) {
+		settings := s.network()
+		settings.ReleaseSource = strings.TrimSpace(text)
+		s.saveNetwork(settings)
+	})
+
+	s.mirrorsField.SetOnEnterPressed(func(text string) {
+		settings := s.network()
+		settings.Mirrors = nil
+		for _, mirror := range strings.Split(text, ",") {
+			if mirror = strings.TrimSpace(mirror); mirror != "" {
+				settings.Mirrors = append(settings.Mirrors, mirror)
+			}
+		}
+		s.saveNetwork(settings)
+	})
+
+	s.limitField.SetOnEnterPressed(func(text string) {
+		var limit int64
+		if text = strings.TrimSpace(text); text != "" {
+			var err error
+			if limit, err = strconv.ParseInt(text, 10, 64); err != nil {
+				app.Debug.SetToast(app.Debug.New(err, debug.DataError, debug.ErrDownloadsInvalid))
+				return
+			}
+		}
+		if err := app.SetDownloadLimit(limit); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+
+	s.connectionsField.SetOnEnterPressed(func(text string) {
+		var connections int
+		if text = strings.TrimSpace(text); text != "" {
+			var err error
+			if connections, err = strconv.Atoi(text); err != nil {
+				app.Debug.SetToast(app.Debug.New(err, debug.DataError, debug.ErrDownloadsInvalid))
+				return
+			}
+		}
+		if err := app.SetDownloadConnections(connections); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+
+	s.scheduleField.SetOnEnterPressed(func(text string) {
+		start, end, _ := strings.Cut(text, "-")
+		if err := app.SetDownloadSchedule(strings.TrimSpace(start), strings.TrimSpace(end)); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+
+	s.pauseButton.SetOnDown(func() {
+		if err := app.SetDownloadsPaused(!app.DownloadsPaused()); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	})
+
+	s.newsField.SetOnEnterPressed(func(text string) {
+		go func() {
+			if err := app.SetNewsFeed(githubContext, strings.TrimSpace(text)); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+		}()
+	})
+
+	s.tokenField.SetOnEnterPressed(func(text string) {
+		if !s.checkingToken.CompareAndSwap(false, true) {
+			return
+		}
+		go func() {
+			defer s.checkingToken.Store(false)
+			if err := app.SetToken(githubContext, text); err.Err != nil {
+				app.Debug.SetToast(err)
+				return
+			}
+			s.tokenField.SetText("")
+			app.RecheckNetwork()
+		}()
+	})
+
+	s.removeTokenButton.SetOnDown(func() {
+		go func() {
+			if err := app.ClearToken(); err.Err != nil {
+				app.Debug.SetToast(err)
+				return
+			}
+			app.RecheckNetwork()
+		}()
+	})
+
 	s.clearCacheButton.SetOnDown(func() {
 		if app.FS.IsDir() {
 			if err := GDataM.DeleteObject(configs.Cache); err != nil {
@@ -114,19 +286,34 @@ func (s *Settings) Layout(context *guigui.Context, appender *guigui.ChildWidgetA
 
 	s.deleteFilesButton.SetOnDown(func() {
 		if app.FS.IsDir() {
-			if dir, err := app.FS.LauncherDir(app.Debug); err.Err != nil {
+			s.deleteFilesPopup.Open()
+		}
+	})
+	s.launcherUpdateButton.SetOnDown(func() {
+		if !s.updatingLauncher.CompareAndSwap(false, true) {
+			return
+		}
+		go func() {
+			defer s.updatingLauncher.Store(false)
+			if err := app.UpdateLauncher(githubClient, githubContext, nil); err.Err != nil {
 				app.Debug.SetToast(err)
-			} else {
-				log.Info().Msg("Delete all files")
+			}
+		}()
+	})
 
+	s.rollbackButton.SetOnDown(func() {
+		go func() {
+			if err := app.RollbackLauncher(); err.Err != nil {
+				app.Debug.SetToast(err)
+			}
+		}()
+	})
+
+	s.deleteFilesPopup.SetOnDeleted(func(categories []file.Category) {
+		for _, category := range categories {
+			if category.Name == "Data" {
 				s.colorModeToggle.SetValue(false)
 				s.appScaleDropdownList.SetSelectedItemIndex(2)
-
-				go func() {
-					if err := app.FS.ClearFolder(dir, app.Debug); err.Err != nil {
-						app.Debug.SetToast(err)
-					}
-				}()
 			}
 		}
 	})
@@ -142,6 +329,110 @@ func (s *Settings) Layout(context *guigui.Context, appender *guigui.ChildWidgetA
 	s.clearCacheButton.SetText("Clear cache")
 	s.clearDataButton.SetText("Clear data")
 	s.deleteFilesButton.SetText("Delete all files")
+	s.networkText.SetText("Network")
+	if app.IsInternet() {
+		s.networkStatusText.SetText("Online")
+	} else {
+		s.networkStatusText.SetText(networkStatusText())
+	}
+	s.probeText.SetText("Probe URL")
+	s.proxyText.SetText("Proxy")
+	s.probeField.SetSize(context, int(10*u), int(u))
+	s.proxyField.SetSize(context, int(10*u), int(u))
+	s.networkForm.SetWidth(context, w-int(2*u))
+	s.networkForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.networkText, SecondaryWidget: &s.networkStatusText},
+	})
+	s.probeForm.SetWidth(context, w-int(2*u))
+	s.probeForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.probeText, SecondaryWidget: &s.probeField},
+	})
+	s.proxyForm.SetWidth(context, w-int(2*u))
+	s.proxyForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.proxyText, SecondaryWidget: &s.proxyField},
+	})
+
+	s.sourceText.SetText("Release source")
+	s.sourceField.SetSize(context, int(10*u), int(u))
+	s.sourceForm.SetWidth(context, w-int(2*u))
+	s.sourceForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.sourceText, SecondaryWidget: &s.sourceField},
+	})
+
+	s.mirrorsText.SetText("Mirrors")
+	s.mirrorsField.SetSize(context, int(10*u), int(u))
+	s.mirrorsForm.SetWidth(context, w-int(2*u))
+	s.mirrorsForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.mirrorsText, SecondaryWidget: &s.mirrorsField},
+	})
+
+	s.limitText.SetText("Speed limit (KiB/s)")
+	s.limitField.SetSize(context, int(10*u), int(u))
+	s.limitForm.SetWidth(context, w-int(2*u))
+	s.limitForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.limitText, SecondaryWidget: &s.limitField},
+	})
+	s.connectionsText.SetText(fmt.Sprintf("Connections (default %d)", configs.DownloadConnections))
+	s.connectionsField.SetSize(context, int(10*u), int(u))
+	s.connectionsForm.SetWidth(context, w-int(2*u))
+	s.connectionsForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.connectionsText, SecondaryWidget: &s.connectionsField},
+	})
+	s.scheduleText.SetText("Download hours (01:00-07:00)")
+	s.scheduleField.SetSize(context, int(10*u), int(u))
+	s.scheduleForm.SetWidth(context, w-int(2*u))
+	s.scheduleForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.scheduleText, SecondaryWidget: &s.scheduleField},
+	})
+	if app.DownloadsPaused() {
+		s.pauseButton.SetText("Resume downloads")
+	} else {
+		s.pauseButton.SetText("Pause downloads")
+	}
+	s.queueText.SetMultiline(true)
+	if queue := app.DownloadQueue(); len(queue) > 0 {
+		lines := []string{"Download queue:"}
+		for _, name := range queue {
+			lines = append(lines, fmt.Sprintf("%s: %s", name, downloadStateText(name)))
+		}
+		s.queueText.SetText(strings.Join(lines, "\n"))
+	} else {
+		s.queueText.SetText("Download queue is empty")
+	}
+
+	s.newsText.SetText("News feed")
+	s.newsField.SetSize(context, int(10*u), int(u))
+	s.newsForm.SetWidth(context, w-int(2*u))
+	s.newsForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.newsText, SecondaryWidget: &s.newsField},
+	})
+
+	s.tokenText.SetText("GitHub token")
+	s.tokenField.SetSize(context, int(10*u), int(u))
+	s.tokenForm.SetWidth(context, w-int(2*u))
+	s.tokenForm.SetItems([]*widget.FormItem{
+		{PrimaryWidget: &s.tokenText, SecondaryWidget: &s.tokenField},
+	})
+	s.rateLimitText.SetText(rateLimitText(s.checkingToken.Load()))
+	s.removeTokenButton.SetText("Remove token")
+
+	s.rollbackButton.SetText("Rollback launcher")
+	if latest := app.LauncherUpdate(); latest != nil {
+		if s.updatingLauncher.Load() {
+			s.launcherUpdateButton.SetText("Updating launcher...")
+			guigui.Disable(&s.launcherUpdateButton)
+		} else {
+			s.launcherUpdateButton.SetText(fmt.Sprintf("Update launcher to %s", latest.Tag))
+			guigui.Enable(&s.launcherUpdateButton)
+		}
+	}
+	s.usageText.SetMultiline(true)
+	if usage := s.usage.Load(); usage != nil {
+		s.usageText.SetText(fmt.Sprintf("Games: %s\nDownloads: %s\nCache: %s\nLogs: %s\nTotal: %s",
+			file.FormatSize(usage.Games), file.FormatSize(usage.Downloads), file.FormatSize(usage.Cache), file.FormatSize(usage.Logs), file.FormatSize(usage.Total())))
+	} else {
+		s.usageText.SetText("Calculating disk usage...")
+	}
 
 	s.colorModeForm.SetItems([]*widget.FormItem{
 		{PrimaryWidget: &s.colorModeText, SecondaryWidget: &s.colorModeToggle},
@@ -155,17 +446,44 @@ func (s *Settings) Layout(context *guigui.Context, appender *guigui.ChildWidgetA
 	s.vLayout.SetWidth(context, w-int(1*u))
 	guigui.SetPosition(&s.vLayout, pt)
 
-	s.vLayout.SetItems([]*widget.LayoutItem{
+	items := []*widget.LayoutItem{
 		{Widget: &s.colorModeForm},
 		{Widget: &s.appScaleText},
 		{Widget: &s.appScaleDropdownList},
+		{Widget: &s.networkForm},
+		{Widget: &s.probeForm},
+		{Widget: &s.proxyForm},
+		{Widget: &s.sourceForm},
+		{Widget: &s.mirrorsForm},
+		{Widget: &s.limitForm},
+		{Widget: &s.connectionsForm},
+		{Widget: &s.scheduleForm},
+		{Widget: &s.pauseButton},
+		{Widget: &s.queueText},
+		{Widget: &s.newsForm},
+		{Widget: &s.tokenForm},
+		{Widget: &s.rateLimitText},
+	}
+	if app.HasToken() {
+		items = append(items, &widget.LayoutItem{Widget: &s.removeTokenButton})
+	}
+	items = append(items, []*widget.LayoutItem{
 		{Widget: &s.openFolderButton},
 		{Widget: &s.repairButton},
 		{Widget: &s.clearCacheButton},
 		{Widget: &s.clearDataButton},
 		{Widget: &s.deleteFilesButton},
-	})
+		{Widget: &s.usageText},
+	}...)
+	if app.LauncherUpdate() != nil {
+		items = append(items, &widget.LayoutItem{Widget: &s.launcherUpdateButton})
+	}
+	if selfupdate.CanRollback() {
+		items = append(items, &widget.LayoutItem{Widget: &s.rollbackButton})
+	}
+	s.vLayout.SetItems(items)
 	appender.AppendChildWidget(&s.vLayout)
+	appender.AppendChildWidget(&s.deleteFilesPopup)
 }
 
 func (s *Settings) Update(context *guigui.Context) error {
@@ -173,9 +491,59 @@ func (s *Settings) Update(context *guigui.Context) error {
 		AppErr = s.err
 		return s.err.Err
 	}
+
+	// Installs and deletions change the usage.
+	if version := app.FilesVersion(); version != s.filesVersion {
+		s.filesVersion = version
+		s.RefreshUsage()
+	}
 	return nil
 }
 
+// RefreshUsage recomputes the disk usage in the background. A refresh asked
+// for while one runs happens once it finishes.
+func (s *Settings) RefreshUsage() {
+	s.usageStale.Store(true)
+	if !s.usageRunning.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		for s.usageStale.Swap(false) {
+			s.updateUsage()
+		}
+		s.usageRunning.Store(false)
+		if s.usageStale.Load() {
+			s.RefreshUsage()
+		}
+	}()
+}
+
+// network returns a copy of the network settings to change and save.
+func (s *Settings) network() data.Network {
+	return app.Data.Network()
+}
+
+func (s *Settings) saveNetwork(settings data.Network) {
+	if err := app.Data.SaveNetwork(app.Debug, settings); err.Err != nil {
+		app.Debug.SetToast(err)
+		return
+	}
+	log.Info().Str("ProbeURL", settings.ProbeURL).Bool("Proxy", settings.Proxy != "").Str("ReleaseSource", settings.ReleaseSource).Msg("Network settings changed")
+	app.RecheckNetwork()
+}
+
+func (s *Settings) updateUsage() {
+	if !app.FS.IsDir() {
+		return
+	}
+	usage, err := app.FS.Usage(app.Debug)
+	if err.Err != nil {
+		app.Debug.SetToast(err)
+		return
+	}
+	s.usage.Store(usage)
+}
+
 func (s *Settings) Size(context *guigui.Context) (int, int) {
 	w, h := guigui.Parent(s).Size(context)
 	w -= sidebarWidth(context)
@@ -34,6 +34,8 @@ type Sidebar struct {
 	sidebar         basicwidget.Sidebar
 	list            basicwidget.List
 	listItemWidgets []basicwidget.ListItem
+	// selectTag is applied once the items exist.
+	selectTag string
 
 	initOnce sync.Once
 }
@@ -127,6 +129,14 @@ func (s *Sidebar) Layout(context *guigui.Context, appender *guigui.ChildWidgetAp
 	s.initOnce.Do(func() {
 		s.list.SetSelectedItemIndex(0)
 	})
+	if s.selectTag != "" {
+		for index, item := range s.listItemWidgets {
+			if item.Tag == s.selectTag {
+				s.list.SetSelectedItemIndex(index)
+			}
+		}
+		s.selectTag = ""
+	}
 }
 
 func (s *Sidebar) Update(context *guigui.Context) error {
@@ -157,3 +167,8 @@ func (s *Sidebar) SelectedItemTag() string {
 func (s *Sidebar) SetSelectedItemIndex(index int) {
 	s.list.SetSelectedItemIndex(index)
 }
+
+// SelectItemTag opens the page tagged tag.
+func (s *Sidebar) SelectItemTag(tag string) {
+	s.selectTag = tag
+}
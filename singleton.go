@@ -23,14 +23,22 @@ package p86l
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"p86l/configs"
 	ESApp "p86l/internal/app"
 	"p86l/internal/cache"
 	"p86l/internal/data"
 	"p86l/internal/debug"
+	"p86l/internal/deeplink"
 	"p86l/internal/file"
+	"p86l/internal/network"
+	"p86l/internal/single"
+	"p86l/internal/version"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-github/v69/github"
@@ -52,11 +60,13 @@ var (
 
 	AppErr        *debug.Error
 	app           *ESApp.App
-	githubClient  = github.NewClient(nil)
+	githubClient  *github.Client
 	githubContext = context.Background()
 )
 
-func Run() *debug.Error {
+// initApp loads everything the launcher needs, with or without a window.
+// In release builds logs go to out and to a log file.
+func initApp(out io.Writer) *debug.Error {
 	app = &ESApp.App{
 		Debug: &debug.Debug{},
 		FS:    &file.AppFS{GdataM: GDataM},
@@ -85,22 +95,171 @@ func Run() *debug.Error {
 
 		TheDebugMode.LogFile = logFile
 
-		multi := zerolog.MultiLevelWriter(os.Stdout, logFile)
+		multi := zerolog.MultiLevelWriter(out, logFile)
 		log.Logger = zerolog.New(multi).With().Timestamp().Logger()
 	}
+	log.Info().Str("Version", version.Get().String()).Msg("Build")
+
+	if err := app.Data.InitNetwork(app.Debug); err.Err != nil {
+		return err
+	}
+	if err := app.InitToken(); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+	githubClient = github.NewClient(app.HTTPClient())
+	githubClient.UserAgent = version.UserAgent()
 
 	app.Data.ColorMode = guigui.ColorModeLight
 	app.Data.AppScale = 2
+	if err := app.Data.InitInstances(app.Debug); err.Err != nil {
+		return err
+	}
+	if err := app.InitDownloads(); err.Err != nil {
+		return err
+	}
+	if err := app.Data.InitNews(app.Debug); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+
+	if err := app.LoadManifest(); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+	if err := app.Cache.LoadChangelog(app.Debug); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+	if err := app.Cache.LoadMirrors(app.Debug); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+	if err := app.Cache.LoadLinks(app.Debug); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+	if err := app.Cache.LoadFeed(app.Debug); err.Err != nil {
+		app.Debug.SetToast(err)
+	}
+
+	return app.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
+}
+
+// raiseWindow asks Root to bring the window to the front.
+var raiseWindow atomic.Bool
+
+// pendingLink is a deep link waiting for Root to route it.
+var pendingLink atomic.Pointer[deeplink.Link]
+
+// handleArgs runs a launch command or hands the deep links in args to the
+// window. Any web page can open a link, so they only act once confirmed
+// there.
+func handleArgs(args []string) {
+	if name, opts, ok := forwardedLaunch(args); ok {
+		if err := app.LaunchWith(name, opts); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+		return
+	}
+	for _, arg := range args {
+		if !deeplink.IsLink(arg) {
+			continue
+		}
+		link, _err := deeplink.Parse(arg)
+		if _err != nil {
+			app.Debug.SetToast(app.Debug.New(_err, debug.AppError, debug.ErrLinkInvalid))
+			continue
+		}
+		log.Info().Str("Link", link.String()).Msg("Deep link")
+		pendingLink.Store(link)
+	}
+}
 
+// handleForwarded runs the arguments of a launcher started while this one
+// was running.
+func handleForwarded(args []string) {
+	log.Info().Strs("Args", args).Msg("Forwarded launch")
+	raiseWindow.Store(true)
+	handleArgs(args)
+}
+
+// registerLinks makes this launcher the handler of deep links. Development
+// builds leave the registration alone, as they run from temporary paths.
+func registerLinks() {
+	exe, err := os.Executable()
+	if err != nil {
+		log.Warn().Err(err).Msg("Register deep links")
+		return
+	}
+	if err := deeplink.Register(exe); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+		log.Warn().Err(err).Msg("Register deep links")
+		return
+	}
+	log.Info().Str("Executable", exe).Msg("Deep links registered")
+}
+
+// serveLock handles the launches forwarded to lock. Launcher restarts hand
+// it over to the new launcher, and take it back when they fail.
+func serveLock(lock *single.Lock) {
+	app.SetLockHandover(func() { lock.Close() }, func() {
+		relocked, err := single.Acquire(configs.AppName)
+		if err != nil {
+			log.Warn().Err(err).Msg("Single instance lock unavailable")
+			return
+		}
+		serveLock(relocked)
+	})
+	go lock.Serve(handleForwarded)
+}
+
+// Run starts the launcher services for the window and handles args, the
+// command line. Launches forwarded to lock, when it is not nil, are handled
+// until it is closed.
+func Run(args []string, lock *single.Lock) *debug.Error {
+	if err := initApp(os.Stdout); err.Err != nil {
+		return err
+	}
+	if TheDebugMode.IsRelease && app.Feature(configs.FeatureDeepLinks) {
+		go registerLinks()
+	}
+	handleArgs(args)
+	// Local feeds do not wait for the network.
 	go func() {
-		app.UpdateInternet()
-		if app.IsInternet() {
-			err := app.Cache.InitChangelog(app.Debug, githubClient, githubContext)
-			if err.Err != nil {
+		if err := app.UpdateNews(githubContext, false); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+	}()
+	if lock != nil {
+		serveLock(lock)
+	}
+
+	app.StartNetworkMonitor(githubContext, func(result network.Result) {
+		if result.Status != network.StatusOnline {
+			return
+		}
+		// The manifest may move the game repository, so it comes first.
+		if err := app.UpdateManifest(githubContext); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+		app.RunQueued()
+		err := app.UpdateChangelog(githubClient, githubContext)
+		if err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+		if err := app.CheckUpdates(githubClient, githubContext); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+		if err := app.CheckRateLimit(githubContext); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+		if err := app.UpdateLinks(githubContext); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+		if err := app.UpdateNews(githubContext, false); err.Err != nil {
+			app.Debug.SetToast(err)
+		}
+		if TheDebugMode.IsRelease {
+			if err := app.CheckLauncherUpdate(githubClient, githubContext, version.Get().Version); err.Err != nil {
 				app.Debug.SetToast(err)
 			}
 		}
-	}()
+	})
+	go app.RunDownloadQueue(githubContext, githubClient, app.Debug.SetToast)
 
 	return app.Debug.New(nil, debug.UnknownError, debug.ErrUnknown)
 }
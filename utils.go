@@ -22,11 +22,102 @@
 package p86l
 
 import (
+	"fmt"
+	"p86l/internal/debug"
+	"p86l/internal/network"
 	"strings"
+	"time"
 
 	"github.com/hajimehoshi/guigui"
+	"github.com/pkg/browser"
 )
 
+func FormatAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%d min ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d h ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d days ago", int(d.Hours()/24))
+}
+
+// networkStatusText describes why the launcher is offline.
+func networkStatusText() string {
+	result := app.NetworkStatus()
+	switch result.Status {
+	case network.StatusGitHubUnreachable:
+		return "GITHUB UNREACHABLE"
+	case network.StatusRateLimited:
+		if !result.Reset.IsZero() {
+			return fmt.Sprintf("RATE LIMITED UNTIL %s", result.Reset.Local().Format("15:04"))
+		}
+		return "RATE LIMITED"
+	case network.StatusUnknown:
+		return "CHECKING CONNECTION"
+	}
+	return "NO INTERNET"
+}
+
+// downloadStateText describes the state of an instance in the download
+// queue.
+func downloadStateText(name string) string {
+	if status := app.ActiveDownload(); status != nil && status.Instance == name {
+		text := "Downloading"
+		if status.Stage == "extract" {
+			text = "Extracting"
+		}
+		if status.Total > 0 {
+			text = fmt.Sprintf("%s %d%%", text, status.Done*100/status.Total)
+		}
+		if app.DownloadsPaused() {
+			text += ", paused"
+		}
+		return text
+	}
+
+	now := time.Now()
+	switch schedule := app.DownloadSchedule(); {
+	case app.DownloadsPaused():
+		return "Queued, paused"
+	case !app.IsInternet():
+		return "Queued until online"
+	case !schedule.Allowed(now):
+		return fmt.Sprintf("Queued until %s", schedule.Next(now).Format("15:04"))
+	}
+	return "Queued"
+}
+
+// rateLimitText shows the GitHub API requests left and where the token is
+// kept.
+func rateLimitText(checking bool) string {
+	if checking {
+		return "Checking token..."
+	}
+	auth := "Anonymous"
+	if app.HasToken() {
+		auth = fmt.Sprintf("Token in %s", app.TokenStore())
+	}
+	rate := app.RateLimit()
+	if rate == nil {
+		return auth
+	}
+	return fmt.Sprintf("%s, %d/%d requests left, resets %s", auth, rate.Remaining, rate.Limit, rate.Reset.Local().Format("15:04"))
+}
+
+// openURL opens url in the browser without blocking the UI. Failures go to
+// the toast.
+func openURL(url string) {
+	go func() {
+		if err := browser.OpenURL(url); err != nil {
+			app.Debug.SetToast(app.Debug.New(err, debug.AppError, debug.ErrBrowserOpen))
+		}
+	}()
+}
+
 func RemoveLineBreaks(input string) string {
 	input = strings.ReplaceAll(input, "\n", "")
 	input = strings.ReplaceAll(input, "\r", "")